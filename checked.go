@@ -0,0 +1,141 @@
+package jellyset
+
+import "fmt"
+
+// SMembersE returns all the members of the set associated with the given key, like SMembers,
+// but reports a missing key as an error instead of returning an empty slice.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A slice containing all the members of the set.
+//   - An error wrapping ErrKeyNotFound if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	members, err := set.SMembersE("nonexistent")
+//
+// In this example, 'err' wraps ErrKeyNotFound.
+func (s *Set) SMembersE(key string) ([]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set, ok := s.lookup(key)
+	if !ok {
+		return nil, keyNotFound(key)
+	}
+
+	return set.list(), nil
+}
+
+// SCardE returns the number of members in the set associated with the given key, like SCard,
+// but reports a missing key as an error instead of returning 0.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - The number of members in the set.
+//   - An error wrapping ErrKeyNotFound if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	size, err := set.SCardE("myset")
+//
+// In this example, 'size' will be 2 and 'err' will be nil.
+func (s *Set) SCardE(key string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set, ok := s.lookup(key)
+	if !ok {
+		return 0, keyNotFound(key)
+	}
+
+	return set.size(), nil
+}
+
+// SRandMemberE returns random members of the set associated with the given key, like SRandMember,
+// but reports a zero count or a missing key as an error. A negative count remains valid and allows
+// repeated members, as in SRandMember.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to retrieve. A negative count allows repeated members.
+//
+// Returns:
+//   - A slice containing the random members.
+//   - ErrEmptyCount if count is 0, or an error wrapping ErrKeyNotFound if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	members, err := set.SRandMemberE("myset", 0)
+//
+// In this example, 'err' will be ErrEmptyCount.
+func (s *Set) SRandMemberE(key string, count int) ([]interface{}, error) {
+	if count == 0 {
+		return nil, ErrEmptyCount
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.exists(key) {
+		return nil, keyNotFound(key)
+	}
+
+	return s.randMember(key, count), nil
+}
+
+// SPopE removes and returns random members of the set associated with the given key, like SPop,
+// but reports a count that is not positive or a missing key as an error.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to pop from the set.
+//
+// Returns:
+//   - A slice containing the popped members.
+//   - ErrEmptyCount if count is 0, ErrNegativeCount if count is negative, or an error wrapping ErrKeyNotFound
+//     if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	popped, err := set.SPopE("myset", -1)
+//
+// In this example, 'err' will be ErrNegativeCount and no member is popped.
+func (s *Set) SPopE(key string, count int) ([]interface{}, error) {
+	switch {
+	case count == 0:
+		return nil, ErrEmptyCount
+	case count < 0:
+		return nil, ErrNegativeCount
+	}
+
+	var popped []interface{}
+	var err error
+	mutations := s.mutate(func() {
+		if !s.exists(key) {
+			err = keyNotFound(key)
+			return
+		}
+
+		popped = s.pop(key, count)
+	})
+
+	s.notify(mutations)
+	return popped, err
+}
+
+// keyNotFound returns an error wrapping ErrKeyNotFound for the given key.
+func keyNotFound(key string) error {
+	return fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+}
@@ -0,0 +1,71 @@
+package jellyset
+
+import (
+	"errors"
+	"testing"
+)
+
+func assertErrorIs(t *testing.T, err, target error) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		t.Errorf("Expected error %v, but got %v", target, err)
+	}
+}
+
+func TestSet_ErrorVariants(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Existing Key", func(t *testing.T) {
+		// Test the error-returning variants on a key that exists.
+		// It ensures that they return the same results as the silent methods and no error.
+		members, err := set.SMembersE("myset")
+		assertErrorIs(t, err, nil)
+		assertSlicesEqualIgnoreOrder(t, members, []interface{}{"a", "b", "c"}, "Existing Key")
+
+		size, err := set.SCardE("myset")
+		assertErrorIs(t, err, nil)
+		assertCountEqual(t, size, 3)
+
+		random, err := set.SRandMemberE("myset", -5)
+		assertErrorIs(t, err, nil)
+		assertCountEqual(t, len(random), 5)
+
+		popped, err := set.SPopE("myset", 1)
+		assertErrorIs(t, err, nil)
+		assertCountEqual(t, len(popped), 1)
+		assertSetSize(t, set, "myset", 2)
+	})
+
+	t.Run("Non-Existent Key", func(t *testing.T) {
+		// Test the error-returning variants on a key that doesn't exist.
+		// It ensures that every variant returns ErrKeyNotFound.
+		_, err := set.SMembersE("nonexistent")
+		assertErrorIs(t, err, ErrKeyNotFound)
+
+		_, err = set.SCardE("nonexistent")
+		assertErrorIs(t, err, ErrKeyNotFound)
+
+		_, err = set.SRandMemberE("nonexistent", 1)
+		assertErrorIs(t, err, ErrKeyNotFound)
+
+		_, err = set.SPopE("nonexistent", 1)
+		assertErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("Invalid Counts", func(t *testing.T) {
+		// Test SPopE and SRandMemberE with counts that are not accepted.
+		// It ensures that the specific sentinel errors are returned and that no member is popped.
+		_, err := set.SRandMemberE("myset", 0)
+		assertErrorIs(t, err, ErrEmptyCount)
+
+		_, err = set.SPopE("myset", 0)
+		assertErrorIs(t, err, ErrEmptyCount)
+
+		_, err = set.SPopE("myset", -1)
+		assertErrorIs(t, err, ErrNegativeCount)
+
+		assertSetSize(t, set, "myset", 2)
+	})
+}
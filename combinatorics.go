@@ -0,0 +1,152 @@
+package jellyset
+
+import "fmt"
+
+// PowerSetMaxCard is the largest set SPowerSet enumerates the subsets of, as a set of n members has 2^n subsets.
+const PowerSetMaxCard = 20
+
+// SPowerSet returns every subset of the set associated with the given key, from the empty set to the whole set,
+// as 2^n slices for a set of n members. Neither the order of the subsets nor the order of the members within them
+// is specified. A non-existent key is treated as an empty set, whose only subset is the empty one.
+// A set of more than PowerSetMaxCard members is not enumerated, and an empty slice is returned; use SPowerSetE
+// to tell it apart.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A slice containing every subset of the set, or an empty slice if the set has more than PowerSetMaxCard members.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("flags", "a", "b")
+//	subsets := set.SPowerSet("flags")
+//
+// In this example, 'subsets' holds [], ["a"], ["b"] and ["a", "b"], in some order.
+func (s *Set) SPowerSet(key string) [][]interface{} {
+	subsets, err := s.SPowerSetE(key)
+	if err != nil {
+		return [][]interface{}{}
+	}
+
+	return subsets
+}
+
+// SPowerSetE returns every subset of the set associated with the given key, like SPowerSet, but reports a set
+// too large to enumerate as an error instead of returning an empty slice.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A slice containing every subset of the set.
+//   - An error wrapping ErrTooLarge if the set has more than PowerSetMaxCard members.
+//
+// Example:
+//
+//	set := New()
+//	for i := 0; i < 21; i++ {
+//		set.SAdd("flags", i)
+//	}
+//	subsets, err := set.SPowerSetE("flags")
+//
+// In this example, 'err' wraps ErrTooLarge.
+func (s *Set) SPowerSetE(key string) ([][]interface{}, error) {
+	s.mu.RLock()
+	members := s.get(key).list()
+	s.mu.RUnlock()
+
+	n := len(members)
+	if n > PowerSetMaxCard {
+		return nil, fmt.Errorf("%w: %q has %d members, more than %d", ErrTooLarge, key, n, PowerSetMaxCard)
+	}
+
+	// Every member is in half of the subsets, so all of them fit in one backing array of n*2^(n-1) members.
+	backing := make([]interface{}, 0, n<<n>>1)
+	subsets := make([][]interface{}, 1<<n)
+
+	for mask := range subsets {
+		start := len(backing)
+		for i, member := range members {
+			if mask&(1<<i) != 0 {
+				backing = append(backing, member)
+			}
+		}
+		subsets[mask] = backing[start:len(backing):len(backing)]
+	}
+
+	return subsets, nil
+}
+
+// SCartesian returns the Cartesian product of the sets associated with keyA and keyB: every ordered pair whose
+// first element is a member of the first set and whose second element is a member of the second set. The order of
+// the pairs is not specified. A non-existent or empty key yields an empty product. As the product of two sets of
+// n members holds n*n pairs, use SCartesianLimit when the sets may be large.
+//
+// Parameters:
+//   - keyA: 	The key associated with the set providing the first element of each pair.
+//   - keyB: 	The key associated with the set providing the second element of each pair.
+//
+// Returns:
+//   - A slice containing every pair of the product.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("colors", "red", "blue")
+//	set.SAdd("sizes", "S", "M", "L")
+//	pairs := set.SCartesian("colors", "sizes")
+//
+// In this example, 'pairs' holds the 6 pairs from ["red", "S"] to ["blue", "L"].
+func (s *Set) SCartesian(keyA, keyB string) [][2]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return cartesian(s.get(keyA), s.get(keyB))
+}
+
+// SCartesianLimit returns the Cartesian product of the sets associated with keyA and keyB, like SCartesian,
+// unless it would hold more than limit pairs, in which case nothing is built and an error is returned.
+//
+// Parameters:
+//   - keyA: 	The key associated with the set providing the first element of each pair.
+//   - keyB: 	The key associated with the set providing the second element of each pair.
+//   - limit: 	The maximum number of pairs of the product.
+//
+// Returns:
+//   - A slice containing every pair of the product.
+//   - An error wrapping ErrTooLarge if the product would hold more than limit pairs.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("colors", "red", "blue")
+//	set.SAdd("sizes", "S", "M", "L")
+//	pairs, err := set.SCartesianLimit("colors", "sizes", 4)
+//
+// In this example, the product would hold 6 pairs, so 'err' wraps ErrTooLarge.
+func (s *Set) SCartesianLimit(keyA, keyB string, limit int) ([][2]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setA, setB := s.get(keyA), s.get(keyB)
+	if setA.size() > 0 && setB.size() > limit/setA.size() {
+		return nil, fmt.Errorf("%w: the product of %q and %q has %d pairs, more than %d",
+			ErrTooLarge, keyA, keyB, setA.size()*setB.size(), limit)
+	}
+
+	return cartesian(setA, setB), nil
+}
+
+// cartesian returns every ordered pair of a member of a and a member of b.
+func cartesian(a, b set) [][2]interface{} {
+	pairs := make([][2]interface{}, 0, a.size()*b.size())
+	for itemA := range a {
+		for itemB := range b {
+			pairs = append(pairs, [2]interface{}{itemA, itemB})
+		}
+	}
+
+	return pairs
+}
@@ -0,0 +1,141 @@
+package jellyset
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// subsetStrings returns a sorted description of subsets, each one with its members sorted, for comparison.
+func subsetStrings(subsets [][]interface{}) []string {
+	result := make([]string, len(subsets))
+	for i, subset := range subsets {
+		members := make([]string, len(subset))
+		for j, member := range subset {
+			members[j] = fmt.Sprint(member)
+		}
+		sort.Strings(members)
+		result[i] = fmt.Sprint(members)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestSet_SPowerSet(t *testing.T) {
+	t.Run("Three-Element Set", func(t *testing.T) {
+		// Test enumerating the subsets of a set of three members.
+		// It ensures that the 8 subsets, from the empty set to the whole set, are each returned once.
+		set := New()
+		set.SAdd("flags", "a", "b", "c")
+
+		subsets := set.SPowerSet("flags")
+		assertCountEqual(t, len(subsets), 8)
+		expected := []string{"[]", "[a b c]", "[a b]", "[a c]", "[a]", "[b c]", "[b]", "[c]"}
+		if actual := subsetStrings(subsets); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("Expected subsets %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Subsets Are Independent", func(t *testing.T) {
+		// Test appending to a subset of the power set.
+		// It ensures that the other subsets are not overwritten.
+		set := New()
+		set.SAdd("flags", "a", "b")
+
+		subsets := set.SPowerSet("flags")
+		before := subsetStrings(subsets)
+		for i := range subsets {
+			_ = append(subsets[i], "x")
+		}
+		if after := subsetStrings(subsets); fmt.Sprint(after) != fmt.Sprint(before) {
+			t.Errorf("Expected subsets %v to be unchanged, but got %v", before, after)
+		}
+	})
+
+	t.Run("Empty Set", func(t *testing.T) {
+		// Test enumerating the subsets of a non-existent key.
+		// It ensures that the only subset is the empty one.
+		subsets := New().SPowerSet("nonexistent")
+		assertCountEqual(t, len(subsets), 1)
+		assertCountEqual(t, len(subsets[0]), 0)
+	})
+
+	t.Run("Cap Enforcement", func(t *testing.T) {
+		// Test enumerating the subsets of a set one member larger than the cap.
+		// It ensures that SPowerSet returns no subset and SPowerSetE an error wrapping ErrTooLarge.
+		set := New()
+		for i := 0; i <= PowerSetMaxCard; i++ {
+			set.SAdd("flags", i)
+		}
+
+		assertCountEqual(t, len(set.SPowerSet("flags")), 0)
+		subsets, err := set.SPowerSetE("flags")
+		assertErrorIs(t, err, ErrTooLarge)
+		assertCountEqual(t, len(subsets), 0)
+
+		set.SRem("flags", 0)
+		subsets, err = set.SPowerSetE("flags")
+		if err != nil {
+			t.Fatalf("Expected no error at the cap, but got %v", err)
+		}
+		assertCountEqual(t, len(subsets), 1<<PowerSetMaxCard)
+	})
+}
+
+func TestSet_SCartesian(t *testing.T) {
+	set := New()
+	set.SAdd("colors", "red", "blue")
+	set.SAdd("sizes", "S", "M", "L")
+	set.SAdd("empty")
+
+	pairStrings := func(pairs [][2]interface{}) []interface{} {
+		result := make([]interface{}, len(pairs))
+		for i, pair := range pairs {
+			result[i] = fmt.Sprint(pair[0], "/", pair[1])
+		}
+		return result
+	}
+
+	t.Run("All Pairs Present", func(t *testing.T) {
+		// Test the product of a set of 2 members and a set of 3 members, in both orders.
+		// It ensures that it holds card(A)*card(B) pairs, each ordered with its first element from A.
+		pairs := set.SCartesian("colors", "sizes")
+		assertCountEqual(t, len(pairs), set.SCard("colors")*set.SCard("sizes"))
+		assertSlicesEqualIgnoreOrder(t, pairStrings(pairs), []interface{}{
+			"red/S", "red/M", "red/L", "blue/S", "blue/M", "blue/L",
+		}, "All Pairs Present")
+
+		reversed := set.SCartesian("sizes", "colors")
+		assertCountEqual(t, len(reversed), 6)
+		assertKeyExists(t, reversed[0][0] != "red" && reversed[0][0] != "blue")
+	})
+
+	t.Run("Missing or Empty Key", func(t *testing.T) {
+		// Test the product of a set with an empty set and with a non-existent key.
+		// It ensures that the product is empty.
+		assertCountEqual(t, len(set.SCartesian("colors", "empty")), 0)
+		assertCountEqual(t, len(set.SCartesian("nonexistent", "sizes")), 0)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		// Test the capped product with limits below, at and above its size.
+		// It ensures that a product over the limit is refused with ErrTooLarge, and that others are built.
+		pairs, err := set.SCartesianLimit("colors", "sizes", 5)
+		assertErrorIs(t, err, ErrTooLarge)
+		assertCountEqual(t, len(pairs), 0)
+
+		for _, limit := range []int{6, 100} {
+			pairs, err = set.SCartesianLimit("colors", "sizes", limit)
+			if err != nil {
+				t.Fatalf("Expected no error with a limit of %d, but got %v", limit, err)
+			}
+			assertCountEqual(t, len(pairs), 6)
+		}
+
+		pairs, err = set.SCartesianLimit("empty", "sizes", 0)
+		if err != nil {
+			t.Fatalf("Expected no error for an empty product, but got %v", err)
+		}
+		assertCountEqual(t, len(pairs), 0)
+	})
+}
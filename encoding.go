@@ -0,0 +1,290 @@
+package jellyset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SEncodeEach gob-encodes every member of the set associated with the given key to w, one member
+// at a time, so that a large set can be exported without first being materialized as a slice.
+// The written stream can be read back member by member with DecodeEach.
+// If the key does not exist, nothing is written and it returns 0.
+//
+// Members are encoded as interface values, so every concrete member type that is not a Go basic type
+// must be registered with gob.Register before encoding (and before decoding on the reading side).
+//
+// Parameters:
+//   - key: 	The key associated with the set to be encoded.
+//   - w: 		The writer the encoded members are written to.
+//
+// Returns:
+//   - The number of members written.
+//   - An error if encoding a member or writing to w failed.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	var buf bytes.Buffer
+//	count, err := set.SEncodeEach("myset", &buf)
+//
+// In this example, the three members of "myset" are encoded into 'buf', and 'count' will be 3.
+func (s *Set) SEncodeEach(key string, w io.Writer) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.exists(key) {
+		return 0, nil
+	}
+
+	enc := gob.NewEncoder(w)
+	written := 0
+
+	for item := range s.get(key) {
+		member := item
+		if err := enc.Encode(&member); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	return written, nil
+}
+
+// DecodeEach decodes a stream produced by SEncodeEach, calling fn for every decoded member.
+// Decoding stops when the stream is exhausted or when fn returns false.
+//
+// As with SEncodeEach, concrete member types that are not Go basic types must be registered
+// with gob.Register before decoding.
+//
+// Parameters:
+//   - r: 		The reader holding the encoded members.
+//   - fn: 		The function called with each decoded member. Returning false stops decoding.
+//
+// Returns:
+//   - The number of members passed to fn.
+//   - An error if the stream could not be decoded.
+//
+// Example:
+//
+//	count, err := DecodeEach(&buf, func(member interface{}) bool {
+//		set.SAdd("restored", member)
+//		return true
+//	})
+//
+// In this example, every member encoded in 'buf' is added to the set "restored."
+func DecodeEach(r io.Reader, fn func(member interface{}) bool) (int, error) {
+	dec := gob.NewDecoder(r)
+	decoded := 0
+
+	for {
+		var member interface{}
+		if err := dec.Decode(&member); err != nil {
+			if errors.Is(err, io.EOF) {
+				return decoded, nil
+			}
+			return decoded, err
+		}
+
+		decoded++
+		if !fn(member) {
+			return decoded, nil
+		}
+	}
+}
+
+// WriteTo implements io.WriterTo. It gob-encodes every key of the Set together with the members of its set
+// to w, so that the whole Set can be persisted and later restored with ReadFrom. Unlike MarshalJSON, members
+// keep their concrete Go types through the round trip.
+//
+// Members are encoded as interface values, so every concrete member type that is not a Go basic type
+// must be registered with gob.Register before writing (and before reading on the restoring side).
+//
+// Parameters:
+//   - w: 		The writer the encoded Set is written to.
+//
+// Returns:
+//   - The number of bytes written.
+//   - An error if encoding the Set or writing to w failed.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", 2)
+//	var buf bytes.Buffer
+//	n, err := set.WriteTo(&buf)
+//
+// In this example, "set1" and its two members are encoded into 'buf', and 'n' holds the encoded size.
+func (s *Set) WriteTo(w io.Writer) (int64, error) {
+	s.mu.RLock()
+	records := make(map[string][]interface{}, len(s.records))
+	for key, set := range s.records {
+		if !s.expired(key) {
+			records[key] = set.list()
+		}
+	}
+	s.mu.RUnlock()
+
+	cw := &countingWriter{w: w}
+	err := gob.NewEncoder(cw).Encode(records)
+
+	return cw.n, err
+}
+
+// ReadFrom implements io.ReaderFrom. It decodes a Set written by WriteTo from r and replaces the whole
+// contents of the Set with it. Keys holding no members are not created.
+//
+// As with WriteTo, concrete member types that are not Go basic types must be registered with gob.Register
+// before reading.
+//
+// Parameters:
+//   - r: 		The reader holding the encoded Set.
+//
+// Returns:
+//   - The number of bytes read from r. The gob decoder may buffer its input, so this can exceed the size of
+//     the encoded Set when r is not an io.ByteReader.
+//   - An error if the Set could not be decoded, wrapping ErrUnsupportedType if a decoded member cannot be
+//     a set member, such as a slice or a map. The Set is left unchanged in that case.
+//
+// Example:
+//
+//	restored := New()
+//	n, err := restored.ReadFrom(&buf)
+//
+// In this example, 'restored' will hold the same keys and members as the Set written to 'buf'.
+func (s *Set) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+
+	var decoded map[string][]interface{}
+	if err := gob.NewDecoder(cr).Decode(&decoded); err != nil {
+		return cr.n, err
+	}
+
+	records := make(map[string]set, len(decoded))
+	for key, members := range decoded {
+		if err := checkMembers(members); err != nil {
+			return cr.n, err
+		}
+
+		if len(members) > 0 {
+			records[key] = setOf(members...)
+		}
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	s.reset(records)
+	return cr.n, nil
+}
+
+// SMarshalBinary gob-encodes the members of the set associated with the given key into an opaque blob, so that
+// a single key can be persisted, for example in a key-value store, and restored with SUnmarshalBinary.
+// As with WriteTo, members keep their concrete Go types, and every concrete member type that is not a Go basic type
+// must be registered with gob.Register.
+//
+// Parameters:
+//   - key: 	The key associated with the set to be encoded.
+//
+// Returns:
+//   - The encoded members.
+//   - An error wrapping ErrKeyNotFound if the key does not exist, or an error if encoding a member failed.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", 2)
+//	data, err := set.SMarshalBinary("myset")
+//
+// In this example, 'data' holds the two members of "myset."
+func (s *Set) SMarshalBinary(key string) ([]byte, error) {
+	s.mu.RLock()
+	set, ok := s.lookup(key)
+	if !ok {
+		s.mu.RUnlock()
+		return nil, keyNotFound(key)
+	}
+	members := set.list()
+	s.mu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(members); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SUnmarshalBinary decodes a blob produced by SMarshalBinary and stores its members into the set associated with
+// the given key, overwriting any existing set and clearing its expiration. The data is fully decoded before the key
+// is written, so invalid data leaves the Set untouched. As with ReadFrom, concrete member types that are not Go
+// basic types must be registered with gob.Register.
+//
+// Parameters:
+//   - key: 	The key associated with the set the members are stored into.
+//   - data: 	The encoded members.
+//
+// Returns:
+//   - An error if the data could not be decoded, wrapping ErrUnsupportedType if a decoded member cannot be
+//     a set member, such as a slice or a map.
+//
+// Example:
+//
+//	set := New()
+//	err := set.SUnmarshalBinary("restored", data)
+//
+// In this example, "restored" holds exactly the members encoded in 'data.'
+func (s *Set) SUnmarshalBinary(key string, data []byte) error {
+	var members []interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&members); err != nil {
+		return err
+	}
+
+	if err := checkMembers(members); err != nil {
+		return err
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	s.store(key, setOf(members...))
+	return nil
+}
+
+// checkMembers returns an error wrapping ErrUnsupportedType if one of the decoded members cannot be a set member.
+func checkMembers(members []interface{}) error {
+	for _, member := range members {
+		if !isComparable(member) {
+			return fmt.Errorf("%w: %T cannot be a set member", ErrUnsupportedType, member)
+		}
+	}
+
+	return nil
+}
+
+// countingWriter is an io.Writer counting the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// countingReader is an io.Reader counting the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,214 @@
+package jellyset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+type encodedPoint struct {
+	X, Y int
+}
+
+func init() {
+	gob.Register(encodedPoint{})
+}
+
+func TestSet_SEncodeEach(t *testing.T) {
+	set := New()
+
+	t.Run("Round Trip Encoded Members", func(t *testing.T) {
+		// Test encoding a set and decoding it back member by member.
+		// It ensures that every member survives the round trip with its concrete type.
+		set.SAdd("myset", "member1", 42, 3.5, encodedPoint{X: 1, Y: 2})
+
+		var buf bytes.Buffer
+		written, err := set.SEncodeEach("myset", &buf)
+		if err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+		assertCountEqual(t, written, 4)
+
+		decoded, err := DecodeEach(&buf, func(member interface{}) bool {
+			set.SAdd("restored", member)
+			return true
+		})
+		if err != nil {
+			t.Fatalf("Expected no error while decoding, but got %v", err)
+		}
+		assertCountEqual(t, decoded, 4)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("restored"), set.SMembers("myset"), "Round Trip Encoded Members")
+	})
+
+	t.Run("Encode Non-Existent Set", func(t *testing.T) {
+		// Test encoding a set that doesn't exist.
+		// It ensures that nothing is written and the decoder yields no members.
+		var buf bytes.Buffer
+		written, err := set.SEncodeEach("nonexistent", &buf)
+		if err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+		assertCountEqual(t, written, 0)
+
+		decoded, err := DecodeEach(&buf, func(member interface{}) bool {
+			t.Errorf("Expected no members to be decoded, but got %v", member)
+			return true
+		})
+		if err != nil {
+			t.Fatalf("Expected no error while decoding, but got %v", err)
+		}
+		assertCountEqual(t, decoded, 0)
+	})
+
+	t.Run("Decode with Early Stop", func(t *testing.T) {
+		// Test stopping the decoder after the first member.
+		// It ensures that the callback is not invoked again once it returns false.
+		set.SAdd("large", "a", "b", "c", "d", "e")
+
+		var buf bytes.Buffer
+		if _, err := set.SEncodeEach("large", &buf); err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+
+		calls := 0
+		decoded, err := DecodeEach(&buf, func(member interface{}) bool {
+			calls++
+			return false
+		})
+		if err != nil {
+			t.Fatalf("Expected no error while decoding, but got %v", err)
+		}
+		assertCountEqual(t, decoded, 1)
+		assertCountEqual(t, calls, 1)
+	})
+}
+
+func TestSet_WriteTo(t *testing.T) {
+	t.Run("Round Trip Several Keys", func(t *testing.T) {
+		// Test writing a Set with several keys and reading it back into a new Set.
+		// It ensures that the byte counts match and that every key keeps its members and their concrete types.
+		set := New()
+		set.SAdd("names", "alice", "bob", "carol")
+		set.SAdd("mixed", 1, int64(2), 3.5, true, encodedPoint{X: 1, Y: 2})
+		set.SAdd("single", "only")
+
+		var buf bytes.Buffer
+		written, err := set.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("Expected no error while writing, but got %v", err)
+		}
+		assertCountEqual(t, int(written), buf.Len())
+
+		restored := New()
+		restored.SAdd("stale", "a")
+		read, err := restored.ReadFrom(&buf)
+		if err != nil {
+			t.Fatalf("Expected no error while reading, but got %v", err)
+		}
+		assertCountEqual(t, int(read), int(written))
+
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(restored.SKeys()), []interface{}{"names", "mixed", "single"}, "Round Trip Several Keys")
+		for _, key := range []string{"names", "mixed", "single"} {
+			assertCountEqual(t, restored.SCard(key), set.SCard(key))
+			assertSlicesEqualIgnoreOrder(t, restored.SMembers(key), set.SMembers(key), key)
+		}
+		assertKeyExists(t, restored.SIsMember("mixed", encodedPoint{X: 1, Y: 2}))
+		assertKeyDoesNotExist(t, restored.SIsMember("mixed", 2))
+	})
+
+	t.Run("Read Invalid Data", func(t *testing.T) {
+		// Test reading data that is not a Set written by WriteTo.
+		// It ensures that an error is returned and the Set is left unchanged.
+		set := New()
+		set.SAdd("myset", "a")
+
+		if _, err := set.ReadFrom(bytes.NewBufferString("not gob")); err == nil {
+			t.Errorf("Expected an error while reading invalid data, but got nil")
+		}
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"a"})
+	})
+
+	t.Run("Read Unsupported Member", func(t *testing.T) {
+		// Test reading a stream holding a member that cannot be a set member.
+		// It ensures that an error wrapping ErrUnsupportedType is returned instead of a panic, and the Set is unchanged.
+		set := New()
+		set.SAdd("myset", "a")
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(map[string][]interface{}{"bad": {"b", []int{1}}}); err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+
+		_, err := set.ReadFrom(&buf)
+		assertErrorIs(t, err, ErrUnsupportedType)
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"a"})
+		assertKeyDoesNotExist(t, set.SKeyExists("bad"))
+	})
+}
+
+func TestSet_SMarshalBinary(t *testing.T) {
+	t.Run("Round Trip a Single Key", func(t *testing.T) {
+		// Test marshaling one key of mixed member types, including a registered struct, and unmarshaling it
+		// over an existing key of another Set.
+		// It ensures that the members keep their concrete types and replace the existing ones, and that
+		// other keys are not affected.
+		set := New()
+		set.SAdd("mixed", "a", 1, int64(1), 2.5, true, encodedPoint{X: 1, Y: 2})
+		set.SAdd("other", "x")
+
+		data, err := set.SMarshalBinary("mixed")
+		if err != nil {
+			t.Fatalf("Expected no error while marshaling, but got %v", err)
+		}
+
+		restored := New()
+		restored.SAdd("target", "stale")
+		restored.SAdd("untouched", "y")
+		if err := restored.SUnmarshalBinary("target", data); err != nil {
+			t.Fatalf("Expected no error while unmarshaling, but got %v", err)
+		}
+
+		assertSlicesEqualIgnoreOrder(t, restored.SMembers("target"), set.SMembers("mixed"), "Round Trip a Single Key")
+		assertKeyExists(t, restored.SIsMember("target", encodedPoint{X: 1, Y: 2}))
+		assertKeyExists(t, restored.SIsMember("target", int64(1)))
+		assertKeyDoesNotExist(t, restored.SIsMember("target", "stale"))
+		assertSlicesEqual(t, restored.SMembers("untouched"), []interface{}{"y"})
+		assertKeyDoesNotExist(t, restored.SKeyExists("other"))
+	})
+
+	t.Run("Marshal Non-Existent Key", func(t *testing.T) {
+		// Test marshaling a key that doesn't exist.
+		// It ensures that an error wrapping ErrKeyNotFound is returned.
+		_, err := New().SMarshalBinary("nonexistent")
+		assertErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("Unmarshal Invalid Data", func(t *testing.T) {
+		// Test unmarshaling data that was not produced by SMarshalBinary.
+		// It ensures that an error is returned and the existing key is left unchanged.
+		set := New()
+		set.SAdd("myset", "a")
+
+		if err := set.SUnmarshalBinary("myset", []byte("not gob")); err == nil {
+			t.Errorf("Expected an error while unmarshaling invalid data, but got nil")
+		}
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"a"})
+	})
+
+	t.Run("Unmarshal Unsupported Member", func(t *testing.T) {
+		// Test unmarshaling a blob holding a member that cannot be a set member.
+		// It ensures that an error wrapping ErrUnsupportedType is returned instead of a panic,
+		// that the key is left unchanged, and that the Set is still usable.
+		set := New()
+		set.SAdd("myset", "a")
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode([]interface{}{[]int{1}}); err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+
+		err := set.SUnmarshalBinary("myset", buf.Bytes())
+		assertErrorIs(t, err, ErrUnsupportedType)
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"a"})
+	})
+}
@@ -0,0 +1,30 @@
+package jellyset
+
+import "errors"
+
+// ErrTypeMismatch is returned by the generic helpers when a member is not of the requested type.
+var ErrTypeMismatch = errors.New("jellyset: member type mismatch")
+
+// ErrUnsupportedType is returned when a member cannot be encoded in the requested format.
+var ErrUnsupportedType = errors.New("jellyset: unsupported member type")
+
+// ErrInvalidMsgpack is returned when decoding data that is not a valid MessagePack array of supported members.
+var ErrInvalidMsgpack = errors.New("jellyset: invalid msgpack data")
+
+// ErrKeyNotFound is returned by the error-returning variants of the operations when the key does not exist.
+var ErrKeyNotFound = errors.New("jellyset: key not found")
+
+// ErrEmptyCount is returned by SPopE and SRandMemberE when asked for zero members.
+var ErrEmptyCount = errors.New("jellyset: count must not be zero")
+
+// ErrNegativeCount is returned by SPopE when asked for a negative number of members.
+var ErrNegativeCount = errors.New("jellyset: count must not be negative")
+
+// ErrMaxCardReached is returned by SAddBounded when the set is full and members were left out.
+var ErrMaxCardReached = errors.New("jellyset: maximum cardinality reached")
+
+// ErrIntSetRange is returned by IntSet when a member is negative or greater than IntSetMaxMember.
+var ErrIntSetRange = errors.New("jellyset: IntSet member out of range")
+
+// ErrTooLarge is returned when the result of an operation would hold more elements than its limit allows.
+var ErrTooLarge = errors.New("jellyset: result too large")
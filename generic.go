@@ -0,0 +1,100 @@
+package jellyset
+
+import "fmt"
+
+// AppendMembersAs appends the members of the set associated with the given key onto dst, converting each
+// of them to T. The capacity of dst is reused, so a buffer can be passed back in across calls to avoid allocations.
+// If the key does not exist, dst is returned unchanged.
+//
+// The conversion stops at the first member that is not a T and an error wrapping ErrTypeMismatch is returned.
+// In that case the returned slice still holds the members appended before the offending one, since members
+// are visited in no particular order callers should usually discard it.
+//
+// Parameters:
+//   - s: 		The Set holding the set.
+//   - key: 	The key associated with the set.
+//   - dst: 	The slice the members are appended to.
+//
+// Returns:
+//   - The extended slice.
+//   - An error wrapping ErrTypeMismatch if a member is not of type T.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("ids", 1, 2, 3)
+//	buf := make([]int, 0, 16)
+//	buf, err := AppendMembersAs(set, "ids", buf[:0])
+//
+// In this example, 'buf' will contain 1, 2 and 3 and 'err' will be nil.
+func AppendMembersAs[T any](s *Set, key string, dst []T) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.exists(key) {
+		return dst, nil
+	}
+
+	for item := range s.get(key) {
+		member, ok := item.(T)
+		if !ok {
+			return dst, fmt.Errorf("%w: %v is %T, not %T", ErrTypeMismatch, item, item, member)
+		}
+		dst = append(dst, member)
+	}
+
+	return dst, nil
+}
+
+// PopAs removes and returns up to count random members from the set associated with the given key,
+// converting each of them to T. If count exceeds the size of the set, every member is popped.
+// If the key does not exist or the count is less than or equal to 0, it returns an empty slice.
+//
+// If any drawn member is not a T, no member is removed and an error wrapping ErrTypeMismatch is returned,
+// so a failed pop leaves the set, its expiration and its insertion order as they were.
+//
+// Parameters:
+//   - s: 		The Set holding the set.
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to pop from the set.
+//
+// Returns:
+//   - A slice containing the popped members.
+//   - An error wrapping ErrTypeMismatch if a popped member is not of type T.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("jobs", "job1", "job2", "job3")
+//	jobs, err := PopAs[string](set, "jobs", 2)
+//
+// In this example, two random members are removed from the set "jobs" and returned as a []string.
+func PopAs[T any](s *Set, key string, count int) ([]T, error) {
+	var result []T
+	var err error
+	mutations := s.mutate(func() {
+		if !s.exists(key) || count <= 0 {
+			result = []T{}
+			return
+		}
+
+		// The members are drawn and converted before any is removed, so that a failed pop leaves the set,
+		// its expiration and its insertion order untouched.
+		drawn := s.sample(s.get(key), count)
+		result = make([]T, 0, len(drawn))
+
+		for _, item := range drawn {
+			member, ok := item.(T)
+			if !ok {
+				result, err = []T{}, fmt.Errorf("%w: %v is %T, not %T", ErrTypeMismatch, item, item, member)
+				return
+			}
+			result = append(result, member)
+		}
+
+		s.remMembers(key, drawn...)
+	})
+
+	s.notify(mutations)
+	return result, err
+}
@@ -0,0 +1,157 @@
+package jellyset
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAppendMembersAs(t *testing.T) {
+	set := New()
+
+	t.Run("Append Homogeneous Members", func(t *testing.T) {
+		// Test appending the members of a set of ints.
+		// It ensures that every member is appended with its concrete type.
+		set.SAdd("ids", 1, 2, 3)
+		ids, err := AppendMembersAs(set, "ids", []int(nil))
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertSlicesEqualIgnoreOrder(t, intsToInterfaces(ids), []interface{}{1, 2, 3}, "Append Homogeneous Members")
+	})
+
+	t.Run("Reuse Buffer Across Calls", func(t *testing.T) {
+		// Test reusing the same buffer across multiple calls.
+		// It ensures that the existing capacity is reused instead of allocating a new array.
+		set.SAdd("more", 4, 5)
+		buf := make([]int, 0, 8)
+
+		buf, err := AppendMembersAs(set, "ids", buf[:0])
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		first := &buf[:1][0]
+
+		buf, err = AppendMembersAs(set, "more", buf[:0])
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if &buf[:1][0] != first || cap(buf) != 8 {
+			t.Errorf("Expected the buffer to be reused, but a new one was allocated")
+		}
+		assertSlicesEqualIgnoreOrder(t, intsToInterfaces(buf), []interface{}{4, 5}, "Reuse Buffer Across Calls")
+	})
+
+	t.Run("Append Keeps Existing Elements", func(t *testing.T) {
+		// Test appending onto a non-empty slice.
+		// It ensures that the existing elements are kept in front.
+		ids, err := AppendMembersAs(set, "more", []int{0})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertCountEqual(t, len(ids), 3)
+		assertCountEqual(t, ids[0], 0)
+	})
+
+	t.Run("Type Mismatch", func(t *testing.T) {
+		// Test appending the members of a set containing a member of another type.
+		// It ensures that an error wrapping ErrTypeMismatch is returned.
+		set.SAdd("mixed", 1, "two", 3)
+		_, err := AppendMembersAs(set, "mixed", []int(nil))
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("Expected ErrTypeMismatch, but got %v", err)
+		}
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test appending the members of a non-existent set.
+		// It ensures that the destination is returned unchanged.
+		ids, err := AppendMembersAs(set, "nonexistent", []int{7})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertSlicesEqual(t, intsToInterfaces(ids), []interface{}{7})
+	})
+}
+
+// Helper function to convert a slice of ints to a slice of interfaces.
+func intsToInterfaces(ints []int) []interface{} {
+	result := make([]interface{}, len(ints))
+	for i, v := range ints {
+		result[i] = v
+	}
+	return result
+}
+
+func TestPopAs(t *testing.T) {
+	set := New()
+
+	t.Run("Pop from Homogeneous Set", func(t *testing.T) {
+		// Test popping members from a set of strings.
+		// It ensures that the popped members are returned as strings and removed from the set.
+		set.SAdd("jobs", "job1", "job2", "job3")
+		jobs, err := PopAs[string](set, "jobs", 2)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertCountEqual(t, len(jobs), 2)
+		assertSetSize(t, set, "jobs", 1)
+		for _, job := range jobs {
+			assertKeyDoesNotExist(t, set.SIsMember("jobs", job))
+		}
+	})
+
+	t.Run("Pop More than Set Size", func(t *testing.T) {
+		// Test popping more members than the set holds.
+		// It ensures that the count is clamped to the size of the set.
+		set.SAdd("small", 1, 2, 3)
+		ids, err := PopAs[int](set, "small", 10)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertSlicesEqualIgnoreOrder(t, intsToInterfaces(ids), []interface{}{1, 2, 3}, "Pop More than Set Size")
+		assertSetSize(t, set, "small", 0)
+	})
+
+	t.Run("Pop with Type Mismatch", func(t *testing.T) {
+		// Test popping from a set containing a member of another type.
+		// It ensures that an error is returned and no member is removed.
+		set.SAdd("mixed", 1, "two", 3)
+		ids, err := PopAs[int](set, "mixed", 3)
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("Expected ErrTypeMismatch, but got %v", err)
+		}
+		assertCountEqual(t, len(ids), 0)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("mixed"), []interface{}{1, "two", 3}, "Pop with Type Mismatch")
+	})
+
+	t.Run("Failed Pop Keeps Expiration and Order", func(t *testing.T) {
+		// Test a failing pop of every member of a key with a time to live, in a Set recording insertion order.
+		// It ensures that the key keeps its expiration and its members keep their order, and that nothing is
+		// reported as removed.
+		clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		ordered := New(WithClock(clock.Now), WithInsertionOrder())
+		ordered.SAdd("mixed", 1, "two", 3)
+		ordered.SExpire("mixed", time.Minute)
+		removed := 0
+		ordered.OnRemove(func(key string, member interface{}) { removed++ })
+
+		_, err := PopAs[int](ordered, "mixed", 3)
+		assertErrorIs(t, err, ErrTypeMismatch)
+		assertCountEqual(t, removed, 0)
+		if ttl := ordered.STTL("mixed"); ttl != time.Minute {
+			t.Errorf("Expected the time to live to be kept, but got %v", ttl)
+		}
+		assertSlicesEqual(t, ordered.SDiffOrdered("mixed"), []interface{}{1, "two", 3})
+	})
+
+	t.Run("Pop from Non-Existent Set", func(t *testing.T) {
+		// Test popping from a non-existent set.
+		// It ensures that an empty slice is returned without error.
+		ids, err := PopAs[int](set, "nonexistent", 2)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		assertCountEqual(t, len(ids), 0)
+	})
+}
@@ -0,0 +1,114 @@
+package jellyset
+
+import "unicode/utf8"
+
+// globMatch reports whether str matches the Redis-style glob pattern. The pattern supports:
+//   - *		matching any sequence of characters, including an empty one.
+//   - ?		matching exactly one character.
+//   - [abc]	matching one of the listed characters, with ranges such as [a-z] and negation such as [^a].
+//   - \x		matching the character x literally.
+//
+// It backtracks only to the last '*' seen, so matching takes O(len(pattern)*len(str)) time.
+func globMatch(pattern, str string) bool {
+	px, sx := 0, 0
+	starP, starS := -1, 0
+
+	for {
+		if px < len(pattern) && pattern[px] == '*' {
+			starP, starS = px, sx
+			px++
+			continue
+		}
+
+		if px < len(pattern) {
+			if pSize, sSize, ok := matchToken(pattern[px:], str[sx:]); ok {
+				px += pSize
+				sx += sSize
+				continue
+			}
+		} else if sx == len(str) {
+			return true
+		}
+
+		// Let the last '*' absorb one more character and retry the rest of the pattern from there.
+		if starP < 0 || starS == len(str) {
+			return false
+		}
+		_, size := utf8.DecodeRuneInString(str[starS:])
+		starS += size
+		px, sx = starP+1, starS
+	}
+}
+
+// matchToken matches the first token of pattern, which must not be '*', against the start of str.
+// It returns the number of bytes of the token and of the matched character, and whether it matched.
+func matchToken(pattern, str string) (pSize, sSize int, ok bool) {
+	if len(str) == 0 {
+		return 0, 0, false
+	}
+	sr, sSize := utf8.DecodeRuneInString(str)
+
+	switch pattern[0] {
+	case '?':
+		return 1, sSize, true
+
+	case '[':
+		matched, rest, ok := matchClass(pattern[1:], sr)
+		if !ok {
+			// An unterminated class is matched as a literal '['.
+			return 1, 1, str[0] == '['
+		}
+		return len(pattern) - len(rest), sSize, matched
+
+	case '\\':
+		if len(pattern) > 1 {
+			pr, size := utf8.DecodeRuneInString(pattern[1:])
+			return 1 + size, sSize, pr == sr
+		}
+	}
+
+	pr, size := utf8.DecodeRuneInString(pattern)
+	return size, sSize, pr == sr
+}
+
+// matchClass matches r against the character class starting right after its opening '['.
+// It returns whether r is matched, the pattern following the closing ']', and false
+// if the class is not terminated.
+func matchClass(class string, r rune) (matched bool, rest string, ok bool) {
+	negate := false
+	if len(class) > 0 && class[0] == '^' {
+		negate = true
+		class = class[1:]
+	}
+
+	for first := true; len(class) > 0; first = false {
+		if class[0] == ']' && !first {
+			return matched != negate, class[1:], true
+		}
+
+		if class[0] == '\\' && len(class) > 1 {
+			class = class[1:]
+		}
+		lo, size := utf8.DecodeRuneInString(class)
+		class = class[size:]
+
+		hi := lo
+		if len(class) > 1 && class[0] == '-' && class[1] != ']' {
+			class = class[1:]
+			if class[0] == '\\' && len(class) > 1 {
+				class = class[1:]
+			}
+			hi, size = utf8.DecodeRuneInString(class)
+			class = class[size:]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+		}
+
+		if lo <= r && r <= hi {
+			matched = true
+		}
+	}
+
+	return false, "", false
+}
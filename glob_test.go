@@ -0,0 +1,62 @@
+package jellyset
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern, str string
+		expected     bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"user:*", "user:42", true},
+		{"user:*", "session:42", false},
+		{"*:42", "user:42", true},
+		{"u*r*2", "user:42", true},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h?llo", "héllo", true},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-c]llo", "hbllo", true},
+		{"h[a-c]llo", "hdllo", false},
+		{"[]]", "]", true},
+		{"h\\*llo", "h*llo", true},
+		{"h\\*llo", "hello", false},
+		{"h[llo", "h[llo", true},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"a*b*c", "abxbxc", true},
+		{"a*b*c", "abxbxcx", false},
+		{"*\\*", "a*", true},
+		{"*?", "", false},
+		{"*é", "aé", true},
+	}
+
+	for _, c := range cases {
+		if matched := globMatch(c.pattern, c.str); matched != c.expected {
+			t.Errorf("Expected globMatch(%q, %q) to be %v, but got %v", c.pattern, c.str, c.expected, matched)
+		}
+	}
+}
+
+func TestGlobMatch_ManyStars(t *testing.T) {
+	// Test a pattern of many stars that cannot match a long string.
+	// It ensures that matching does not backtrack exponentially, which would take minutes here.
+	pattern := strings.Repeat("a*", 20) + "b"
+	str := strings.Repeat("a", 4000)
+
+	start := time.Now()
+	if globMatch(pattern, str) {
+		t.Errorf("Expected %q not to match %d a's", pattern, len(str))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected matching to take well under a second, but it took %v", elapsed)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/davidandw190/jellyset
 
-go 1.21.0
+go 1.23.0
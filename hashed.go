@@ -0,0 +1,231 @@
+package jellyset
+
+import (
+	"fmt"
+	"sync"
+)
+
+// HashedSet is a variant of Set whose members are identified by a hash computed from their value rather than by
+// comparing them with ==, so that it can hold members that cannot be map keys, such as byte slices or structs
+// holding slices. Two members with the same hash are the same member: adding the second one has no effect, and
+// the first value added is the one listed. It offers the basic operations of Set under the same names.
+// A HashedSet shares no state with any Set. It is safe for concurrent use by multiple goroutines.
+type HashedSet struct {
+	mu      sync.RWMutex
+	hashFn  func(member interface{}) string
+	records map[string]map[string]interface{}
+}
+
+// NewHashed creates and returns a new empty HashedSet identifying members by hashFn, which must return the same
+// string for members considered equal and different strings otherwise. If hashFn is nil, members are identified
+// by their type and Go-syntax representation, which compares slices, arrays, maps and structs by content,
+// but pointers by address.
+//
+// Example:
+//
+//	set := NewHashed(nil)
+//	set.SAdd("blobs", []byte("abc"), []byte("abc"), []byte("def"))
+//	count := set.SCard("blobs")
+//
+// In this example, the two equal byte slices are one member, and 'count' will be 2.
+func NewHashed(hashFn func(member interface{}) string) *HashedSet {
+	if hashFn == nil {
+		hashFn = defaultHash
+	}
+
+	return &HashedSet{
+		hashFn:  hashFn,
+		records: make(map[string]map[string]interface{}),
+	}
+}
+
+// defaultHash identifies a member by its type and Go-syntax representation.
+func defaultHash(member interface{}) string {
+	return fmt.Sprintf("%T\x00%#v", member, member)
+}
+
+// SAdd adds one or more members to the set associated with the provided key, creating it if needed.
+// Members whose hash is already in the set are not added. Byte slices are copied, so the caller may reuse them.
+// Other members holding references, such as slices of other types or structs holding slices, are kept as they
+// are and must not be modified once added, or their hash would no longer match their value.
+//
+// Returns:
+//   - The number of members added to the set.
+func (s *HashedSet) SAdd(key string, members ...interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.records[key]
+	if !ok {
+		set = make(map[string]interface{})
+		s.records[key] = set
+	}
+
+	added := 0
+	for _, member := range members {
+		hash := s.hashFn(member)
+		if _, exists := set[hash]; !exists {
+			if b, ok := member.([]byte); ok {
+				member = append([]byte(nil), b...)
+			}
+
+			set[hash] = member
+			added++
+		}
+	}
+
+	return added
+}
+
+// SRem removes the members with the same hash as the given ones from the set associated with the key,
+// deleting the key once it is empty.
+//
+// Returns:
+//   - The number of members removed from the set.
+func (s *HashedSet) SRem(key string, members ...interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set := s.records[key]
+	removed := 0
+
+	for _, member := range members {
+		hash := s.hashFn(member)
+		if _, exists := set[hash]; exists {
+			delete(set, hash)
+			removed++
+		}
+	}
+
+	if removed > 0 && len(set) == 0 {
+		delete(s.records, key)
+	}
+
+	return removed
+}
+
+// SIsMember checks if a member with the same hash as the given one exists in the set associated with the key.
+func (s *HashedSet) SIsMember(key string, member interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.records[key][s.hashFn(member)]
+	return exists
+}
+
+// SCard returns the number of members in the set associated with the given key.
+func (s *HashedSet) SCard(key string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.records[key])
+}
+
+// SMembers returns all the members of the set associated with the given key, as they were added.
+// The members are those held by the set, so they must not be modified.
+func (s *HashedSet) SMembers(key string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return hashedList(s.records[key])
+}
+
+// SKeyExists checks if the specified key exists in the HashedSet.
+func (s *HashedSet) SKeyExists(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.records[key]
+	return exists
+}
+
+// SClear deletes the specified key and its associated set.
+func (s *HashedSet) SClear(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+}
+
+// SUnion returns the members present in any of the sets associated with the given keys.
+func (s *HashedSet) SUnion(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	union := make(map[string]interface{})
+	for _, key := range keys {
+		for hash, member := range s.records[key] {
+			if _, exists := union[hash]; !exists {
+				union[hash] = member
+			}
+		}
+	}
+
+	return hashedList(union)
+}
+
+// SInter returns the members present in every set associated with the given keys.
+// If any key does not exist, the intersection is empty.
+func (s *HashedSet) SInter(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(keys) == 0 {
+		return []interface{}{}
+	}
+
+	inter := []interface{}{}
+	for hash, member := range s.records[keys[0]] {
+		inAll := true
+		for _, key := range keys[1:] {
+			if _, exists := s.records[key][hash]; !exists {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			inter = append(inter, member)
+		}
+	}
+
+	return inter
+}
+
+// SDiff returns the members of the set associated with the first key that are not in the sets associated
+// with the other keys.
+func (s *HashedSet) SDiff(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(keys) == 0 {
+		return []interface{}{}
+	}
+
+	diff := []interface{}{}
+	for hash, member := range s.records[keys[0]] {
+		excluded := false
+		for _, key := range keys[1:] {
+			if _, exists := s.records[key][hash]; exists {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			diff = append(diff, member)
+		}
+	}
+
+	return diff
+}
+
+// hashedList returns the members of a hashed set as a slice.
+func hashedList(set map[string]interface{}) []interface{} {
+	list := make([]interface{}, 0, len(set))
+	for _, member := range set {
+		list = append(list, member)
+	}
+
+	return list
+}
@@ -0,0 +1,89 @@
+package jellyset
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHashedSet(t *testing.T) {
+	type record struct {
+		Name string
+		Tags []string
+	}
+
+	t.Run("Byte Slices Without Duplicates", func(t *testing.T) {
+		// Test adding byte slices, some equal by content, which would panic in a Set.
+		// It ensures that members equal by content are stored once.
+		set := NewHashed(nil)
+		assertCountEqual(t, set.SAdd("blobs", []byte("abc"), []byte("abc"), []byte("def")), 2)
+		assertCountEqual(t, set.SAdd("blobs", []byte("def")), 0)
+		assertCountEqual(t, set.SCard("blobs"), 2)
+	})
+
+	t.Run("Byte Slices Are Copied", func(t *testing.T) {
+		// Test reusing a byte slice after adding it, as when reading into a buffer.
+		// It ensures that the stored member keeps the content it was added with and can still be found.
+		set := NewHashed(nil)
+		buf := []byte("abc")
+		set.SAdd("blobs", buf)
+		copy(buf, "xyz")
+
+		members := set.SMembers("blobs")
+		assertCountEqual(t, len(members), 1)
+		if !bytes.Equal(members[0].([]byte), []byte("abc")) {
+			t.Errorf("Expected the stored member to be abc, but got %s", members[0])
+		}
+		assertKeyExists(t, set.SIsMember("blobs", []byte("abc")))
+		assertKeyDoesNotExist(t, set.SIsMember("blobs", []byte("xyz")))
+	})
+
+	t.Run("Membership and Removal by Content", func(t *testing.T) {
+		// Test looking up and removing members with equal but distinct values, including structs holding slices.
+		// It ensures that the hash decides the identity, and that the key is deleted once empty.
+		set := NewHashed(nil)
+		set.SAdd("records", record{"a", []string{"x"}}, record{"b", nil})
+
+		assertKeyExists(t, set.SIsMember("records", record{"a", []string{"x"}}))
+		assertKeyDoesNotExist(t, set.SIsMember("records", record{"a", []string{"y"}}))
+		assertCountEqual(t, set.SRem("records", record{"a", []string{"x"}}, record{"c", nil}), 1)
+		assertCountEqual(t, set.SRem("records", record{"b", nil}), 1)
+		assertKeyDoesNotExist(t, set.SKeyExists("records"))
+	})
+
+	t.Run("Custom Hash Function", func(t *testing.T) {
+		// Test a hash function that ignores case.
+		// It ensures that members hashing alike are the same member.
+		set := NewHashed(func(member interface{}) string {
+			return strings.ToLower(fmt.Sprint(member))
+		})
+
+		assertCountEqual(t, set.SAdd("names", "Alice", "ALICE", "bob"), 2)
+		assertKeyExists(t, set.SIsMember("names", "alice"))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("names"), []interface{}{"Alice", "bob"}, "Custom Hash Function")
+	})
+
+	t.Run("Set Algebra by Content", func(t *testing.T) {
+		// Test the union, intersection and difference of sets of byte slices.
+		// It ensures that members are matched by content across sets.
+		set := NewHashed(nil)
+		set.SAdd("a", []byte("1"), []byte("2"), []byte("3"))
+		set.SAdd("b", []byte("2"), []byte("3"), []byte("4"))
+
+		asStrings := func(members []interface{}) []interface{} {
+			result := make([]interface{}, len(members))
+			for i, member := range members {
+				result[i] = string(member.([]byte))
+			}
+			return result
+		}
+
+		assertSlicesEqualIgnoreOrder(t, asStrings(set.SUnion("a", "b")), []interface{}{"1", "2", "3", "4"}, "Union")
+		assertSlicesEqualIgnoreOrder(t, asStrings(set.SInter("a", "b")), []interface{}{"2", "3"}, "Inter")
+		assertSlicesEqualIgnoreOrder(t, asStrings(set.SDiff("a", "b")), []interface{}{"1"}, "Diff")
+		assertEmptySlice(t, set.SInter("a", "nonexistent"))
+		assertCountEqual(t, len(set.SDiff("a", "nonexistent")), 3)
+		assertEmptySlice(t, set.SMembers("nonexistent"))
+	})
+}
@@ -0,0 +1,337 @@
+package jellyset
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// IntSetMaxMember is the largest member an IntSet accepts. An IntSet holding it uses 2 MiB.
+const IntSetMaxMember = 1<<24 - 1
+
+// IntSet is a set of small non-negative integers backed by a bitset, where member n is stored as bit n.
+// For dense members, such as user IDs or feature flags, it uses one bit per possible member instead of a map
+// entry per member, and its union, intersection and difference are computed word by word.
+// Its memory use grows with the largest member, up to IntSetMaxMember, so it is not suited to sparse or large integers.
+// Unlike Set, an IntSet holds a single set and is not safe for concurrent use. A key of a Set declared with
+// SDeclareInt is backed by an IntSet.
+type IntSet struct {
+	words []uint64
+}
+
+// NewIntSet creates and returns a new IntSet holding the given members.
+//
+// Returns:
+//   - The new IntSet.
+//   - An error wrapping ErrIntSetRange if a member is negative or greater than IntSetMaxMember.
+func NewIntSet(members ...int) (*IntSet, error) {
+	s := &IntSet{}
+	if _, err := s.Add(members...); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Add adds one or more members to the IntSet, growing it as needed. If a member is negative or greater than
+// IntSetMaxMember, no member is added.
+//
+// Returns:
+//   - The number of members added to the IntSet.
+//   - An error wrapping ErrIntSetRange if a member is out of range.
+func (s *IntSet) Add(members ...int) (int, error) {
+	for _, member := range members {
+		if member < 0 || member > IntSetMaxMember {
+			return 0, fmt.Errorf("%w: %d", ErrIntSetRange, member)
+		}
+	}
+
+	added := 0
+	for _, member := range members {
+		word, bit := member/64, uint(member%64)
+		if word >= len(s.words) {
+			s.words = append(s.words, make([]uint64, word+1-len(s.words))...)
+		}
+
+		if s.words[word]&(1<<bit) == 0 {
+			s.words[word] |= 1 << bit
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+// Remove removes one or more members from the IntSet. Members that are not in the IntSet are ignored.
+//
+// Returns:
+//   - The number of members removed from the IntSet.
+func (s *IntSet) Remove(members ...int) int {
+	removed := 0
+	for _, member := range members {
+		if s.Contains(member) {
+			s.words[member/64] &^= 1 << uint(member%64)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Contains checks if the member is in the IntSet.
+func (s *IntSet) Contains(member int) bool {
+	if member < 0 || member/64 >= len(s.words) {
+		return false
+	}
+
+	return s.words[member/64]&(1<<uint(member%64)) != 0
+}
+
+// Card returns the number of members in the IntSet.
+func (s *IntSet) Card() int {
+	card := 0
+	for _, word := range s.words {
+		card += bits.OnesCount64(word)
+	}
+
+	return card
+}
+
+// Members returns all the members of the IntSet in ascending order.
+func (s *IntSet) Members() []int {
+	members := make([]int, 0, s.Card())
+	for i, word := range s.words {
+		for word != 0 {
+			members = append(members, i*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+
+	return members
+}
+
+// Union returns a new IntSet holding the members present in s, other, or both.
+func (s *IntSet) Union(other *IntSet) *IntSet {
+	long, short := s.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+
+	words := append([]uint64(nil), long...)
+	for i, word := range short {
+		words[i] |= word
+	}
+
+	return &IntSet{words: words}
+}
+
+// Inter returns a new IntSet holding the members present in both s and other.
+func (s *IntSet) Inter(other *IntSet) *IntSet {
+	words := make([]uint64, min(len(s.words), len(other.words)))
+	for i := range words {
+		words[i] = s.words[i] & other.words[i]
+	}
+
+	return &IntSet{words: words}
+}
+
+// Diff returns a new IntSet holding the members of s that are not in other.
+func (s *IntSet) Diff(other *IntSet) *IntSet {
+	words := append([]uint64(nil), s.words...)
+	for i := range min(len(words), len(other.words)) {
+		words[i] &^= other.words[i]
+	}
+
+	return &IntSet{words: words}
+}
+
+// list returns the members of the IntSet in ascending order, as a slice of interface{}.
+func (s *IntSet) list() []interface{} {
+	members := s.Members()
+	list := make([]interface{}, len(members))
+	for i, member := range members {
+		list[i] = member
+	}
+
+	return list
+}
+
+// intMember returns the member as an int if it is an int that an IntSet accepts.
+func intMember(member interface{}) (int, bool) {
+	n, ok := member.(int)
+	if !ok || n < 0 || n > IntSetMaxMember {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// SDeclareInt declares the key as int-typed, so that its members are stored in an IntSet rather than in a map.
+// SAdd, SRem, SIsMember, SMembers, SCard, SKeyExists, SClear, SInter, SUnion and SDiff reach the bitset of an
+// int-typed key, and SInter, SUnion and SDiff compute word by word when every key they are given is int-typed.
+// On an int-typed key:
+//   - SAdd skips the members that are not ints between 0 and IntSetMaxMember, and does not count them.
+//   - SMembers returns the members in ascending order.
+//   - SClear removes the members but keeps the declaration.
+//   - The store operations, such as SInterStore, read its members like SInter, but a set they store under the
+//     key is kept apart from its bitset.
+//   - Other operations, such as SPop, SMove, SExpire or SUnionCard, do not see the key, and SInterWatch does
+//     not follow its changes.
+//
+// The declaration belongs to the key: it is kept when the bitset is emptied. Declaring a key twice does nothing.
+// If the key already holds a set, its members are moved into the bitset and its expiration is cleared.
+//
+// Parameters:
+//   - key: 	The key to declare as int-typed.
+//
+// Returns:
+//   - An error wrapping ErrTypeMismatch if the set associated with the key holds a member that is not an int,
+//     or ErrIntSetRange if it holds an int that an IntSet does not accept. The key is then left unchanged.
+//
+// Example:
+//
+//	set := New()
+//	err := set.SDeclareInt("users")
+//	set.SAdd("users", 3, 1, 2)
+//	members := set.SMembers("users")
+//
+// In this example, the members of "users" are kept in a bitset, and 'members' will be [1 2 3].
+func (s *Set) SDeclareInt(key string) error {
+	s.lock()
+	defer s.unlock()
+
+	if _, ok := s.ints[key]; ok {
+		return nil
+	}
+
+	ints := &IntSet{}
+	for member := range s.get(key) {
+		n, ok := member.(int)
+		if !ok {
+			return fmt.Errorf("%w: %T in %q", ErrTypeMismatch, member, key)
+		}
+
+		if _, err := ints.Add(n); err != nil {
+			return err
+		}
+	}
+
+	if s.exists(key) {
+		s.drop(key)
+		s.watchCleared(key)
+	}
+
+	s.ints[key] = ints
+	return nil
+}
+
+// addInts adds the members that are ints accepted by an IntSet to the bitset of the int-typed key, reporting
+// each addition like insert. The caller must hold the write lock.
+func (s *Set) addInts(key string, ints *IntSet, members ...interface{}) int {
+	added := 0
+	for _, member := range members {
+		n, ok := intMember(member)
+		if !ok || ints.Contains(n) {
+			continue
+		}
+
+		ints.Add(n)
+		added++
+		if s.stats != nil {
+			s.stats.membersAdded.Add(1)
+		}
+		s.record(true, key, n)
+	}
+
+	return added
+}
+
+// remInts removes the members from the bitset of the int-typed key, reporting each removal like evict.
+// The caller must hold the write lock.
+func (s *Set) remInts(key string, ints *IntSet, members ...interface{}) int {
+	removed := 0
+	for _, member := range members {
+		n, ok := member.(int)
+		if !ok || ints.Remove(n) == 0 {
+			continue
+		}
+
+		removed++
+		if s.stats != nil {
+			s.stats.membersRemoved.Add(1)
+		}
+		s.record(false, key, n)
+	}
+
+	return removed
+}
+
+// view returns the set associated with the key, like lookup, or a copy of the members of its bitset if the key
+// is int-typed. The caller must hold the read lock and must not modify the returned set.
+func (s *Set) view(key string) (set, bool) {
+	if ints, ok := s.ints[key]; ok {
+		if ints.Card() == 0 {
+			return nil, false
+		}
+
+		return setOf(ints.list()...), true
+	}
+
+	return s.lookup(key)
+}
+
+// intKeys returns the bitsets of the keys if there is at least one key and every key is int-typed.
+// The caller must hold the read lock.
+func (s *Set) intKeys(keys []string) ([]*IntSet, bool) {
+	if len(keys) == 0 {
+		return nil, false
+	}
+
+	sets := make([]*IntSet, len(keys))
+	for i, key := range keys {
+		ints, ok := s.ints[key]
+		if !ok {
+			return nil, false
+		}
+		sets[i] = ints
+	}
+
+	return sets, true
+}
+
+// interInts returns the intersection of the bitsets as a slice.
+func interInts(sets []*IntSet) []interface{} {
+	result := sets[0]
+	for _, ints := range sets[1:] {
+		result = result.Inter(ints)
+	}
+
+	return result.list()
+}
+
+// unionInts returns the union of the bitsets as a slice.
+func unionInts(sets []*IntSet) []interface{} {
+	result := &IntSet{}
+	for _, ints := range sets {
+		result = result.Union(ints)
+	}
+
+	return result.list()
+}
+
+// diffInts returns the difference between the first bitset and the others as a slice, following diffMembers:
+// the keys equal to the first one are ignored, and an empty bitset among the others makes the result empty.
+func diffInts(keys []string, sets []*IntSet) []interface{} {
+	result := sets[0]
+	for i, ints := range sets[1:] {
+		if keys[i+1] == keys[0] {
+			continue
+		}
+
+		if ints.Card() == 0 {
+			return []interface{}{}
+		}
+		result = result.Diff(ints)
+	}
+
+	return result.list()
+}
@@ -0,0 +1,297 @@
+package jellyset
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// sortedInts returns the int members of a slice in ascending order.
+func sortedInts(members []interface{}) []int {
+	ints := make([]int, len(members))
+	for i, member := range members {
+		ints[i] = member.(int)
+	}
+	sort.Ints(ints)
+	return ints
+}
+
+// mustIntSet returns a new IntSet holding the given members, failing the test if they are out of range.
+func mustIntSet(t testing.TB, members ...int) *IntSet {
+	t.Helper()
+
+	s, err := NewIntSet(members...)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	return s
+}
+
+// mustAdd adds the members to the IntSet and returns the number added, failing the test if they are out of range.
+func mustAdd(t testing.TB, s *IntSet, members ...int) int {
+	t.Helper()
+
+	added, err := s.Add(members...)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	return added
+}
+
+func assertIntsEqual(t *testing.T, actual, expected []int, message string) {
+	t.Helper()
+
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("%s: Expected %v, but got %v", message, expected, actual)
+	}
+}
+
+func TestIntSet(t *testing.T) {
+	t.Run("Operations Match Set", func(t *testing.T) {
+		// Test every IntSet operation against the same operations on a Set, for random integer inputs.
+		// It ensures that both backends hold and compute the same members.
+		rng := rand.New(rand.NewSource(1))
+		for round := 0; round < 20; round++ {
+			set := New()
+			a, b := mustIntSet(t), mustIntSet(t)
+
+			for i := 0; i < 200; i++ {
+				x, y := rng.Intn(500), rng.Intn(300)
+				assertCountEqual(t, mustAdd(t, a, x), set.SAdd("a", x))
+				assertCountEqual(t, mustAdd(t, b, y), set.SAdd("b", y))
+			}
+
+			for i := 0; i < 50; i++ {
+				x := rng.Intn(600)
+				assertCountEqual(t, a.Remove(x), set.SRem("a", x))
+				assertKeyExists(t, a.Contains(x) == set.SIsMember("a", x))
+			}
+
+			assertCountEqual(t, a.Card(), set.SCard("a"))
+			assertIntsEqual(t, a.Members(), sortedInts(set.SMembers("a")), "Members")
+			assertIntsEqual(t, a.Union(b).Members(), sortedInts(set.SUnion("a", "b")), "Union")
+			assertIntsEqual(t, a.Inter(b).Members(), sortedInts(set.SInter("a", "b")), "Inter")
+			assertIntsEqual(t, b.Inter(a).Members(), sortedInts(set.SInter("b", "a")), "Inter")
+			assertIntsEqual(t, a.Diff(b).Members(), sortedInts(set.SDiff("a", "b")), "Diff")
+			assertIntsEqual(t, b.Diff(a).Members(), sortedInts(set.SDiff("b", "a")), "Diff")
+		}
+	})
+
+	t.Run("Operations Leave Operands Unchanged", func(t *testing.T) {
+		// Test computing the union, intersection and difference of two IntSets.
+		// It ensures that the operands are not modified.
+		a, b := mustIntSet(t, 1, 2, 100), mustIntSet(t, 2, 3)
+		a.Union(b)
+		a.Inter(b)
+		a.Diff(b)
+		b.Union(a)
+
+		assertIntsEqual(t, a.Members(), []int{1, 2, 100}, "Operands")
+		assertIntsEqual(t, b.Members(), []int{2, 3}, "Operands")
+	})
+
+	t.Run("Empty and Out of Range Members", func(t *testing.T) {
+		// Test querying an empty IntSet and members beyond its largest one.
+		// It ensures that such members are reported as absent and not removed.
+		s := mustIntSet(t)
+		assertCountEqual(t, s.Card(), 0)
+		assertCountEqual(t, len(s.Members()), 0)
+		assertKeyDoesNotExist(t, s.Contains(1000))
+		assertKeyDoesNotExist(t, s.Contains(-1))
+		assertCountEqual(t, s.Remove(1000, -1), 0)
+	})
+
+	t.Run("Members Out of Range", func(t *testing.T) {
+		// Test adding negative members and members beyond IntSetMaxMember, alongside valid ones.
+		// It ensures that an error is returned, no member is added and nothing is allocated for the large member.
+		s := mustIntSet(t, 1)
+		for _, member := range []int{-1, IntSetMaxMember + 1, 1 << 40} {
+			added, err := s.Add(2, member)
+			assertErrorIs(t, err, ErrIntSetRange)
+			assertCountEqual(t, added, 0)
+		}
+		assertIntsEqual(t, s.Members(), []int{1}, "Out of Range")
+		assertCountEqual(t, len(s.words), 1)
+
+		_, err := NewIntSet(3, -3)
+		assertErrorIs(t, err, ErrIntSetRange)
+	})
+
+	t.Run("Largest Member", func(t *testing.T) {
+		// Test adding IntSetMaxMember.
+		// It ensures that the largest member is accepted and reported.
+		s := mustIntSet(t, IntSetMaxMember)
+		assertKeyExists(t, s.Contains(IntSetMaxMember))
+		assertIntsEqual(t, s.Members(), []int{IntSetMaxMember}, "Largest Member")
+	})
+}
+
+func TestSet_SDeclareInt(t *testing.T) {
+	t.Run("Declared Keys Match Generic Keys", func(t *testing.T) {
+		// Test SAdd, SRem, SIsMember, SCard, SMembers, SUnion, SInter and SDiff on int-typed keys against the
+		// same operations on a Set without declarations, for random integer inputs.
+		// It ensures that the bitsets hold the members and compute the same results as the generic keys.
+		rng := rand.New(rand.NewSource(1))
+		for round := 0; round < 20; round++ {
+			ints, generic := New(), New()
+			for _, key := range []string{"a", "b", "c"} {
+				if err := ints.SDeclareInt(key); err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+			}
+
+			for i := 0; i < 200; i++ {
+				x, y := rng.Intn(500), rng.Intn(300)
+				assertCountEqual(t, ints.SAdd("a", x), generic.SAdd("a", x))
+				assertCountEqual(t, ints.SAdd("b", y), generic.SAdd("b", y))
+			}
+
+			for i := 0; i < 50; i++ {
+				x := rng.Intn(600)
+				assertCountEqual(t, ints.SRem("a", x), generic.SRem("a", x))
+				assertKeyExists(t, ints.SIsMember("a", x) == generic.SIsMember("a", x))
+			}
+
+			assertCountEqual(t, len(ints.records), 0)
+			assertCountEqual(t, ints.SCard("a"), generic.SCard("a"))
+			assertIntsEqual(t, sortedInts(generic.SMembers("a")), sortedInts(ints.SMembers("a")), "Members")
+			assertIntsEqual(t, ints.ints["a"].Members(), sortedInts(ints.SMembers("a")), "Bitset")
+			for _, keys := range [][]string{{"a", "b"}, {"b", "a"}, {"a", "a"}, {"a", "c"}, {"a"}} {
+				assertIntsEqual(t, sortedInts(ints.SUnion(keys...)), sortedInts(generic.SUnion(keys...)), "Union")
+				assertIntsEqual(t, sortedInts(ints.SInter(keys...)), sortedInts(generic.SInter(keys...)), "Inter")
+				assertIntsEqual(t, sortedInts(ints.SDiff(keys...)), sortedInts(generic.SDiff(keys...)), "Diff")
+			}
+		}
+	})
+
+	t.Run("Members Are Sorted", func(t *testing.T) {
+		// Test SMembers on an int-typed key.
+		// It ensures that the members are returned in ascending order.
+		set := New()
+		if err := set.SDeclareInt("users"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		set.SAdd("users", 300, 1, 64, 2)
+
+		assertSlicesEqual(t, set.SMembers("users"), []interface{}{1, 2, 64, 300})
+	})
+
+	t.Run("Other Members Are Skipped", func(t *testing.T) {
+		// Test adding members that are not ints accepted by an IntSet to an int-typed key.
+		// It ensures that they are skipped and not counted.
+		set := New()
+		if err := set.SDeclareInt("users"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		assertCountEqual(t, set.SAdd("users", "1", 1.5, int64(2), -1, IntSetMaxMember+1, 3), 1)
+		assertSlicesEqual(t, set.SMembers("users"), []interface{}{3})
+		assertKeyDoesNotExist(t, set.SIsMember("users", "3"))
+		assertCountEqual(t, set.SRem("users", "3", -1), 0)
+	})
+
+	t.Run("Existing Members Are Moved", func(t *testing.T) {
+		// Test declaring a key that already holds ints.
+		// It ensures that its members are moved into the bitset.
+		set := New()
+		set.SAdd("users", 2, 1)
+		if err := set.SDeclareInt("users"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		_, ok := set.records["users"]
+		assertKeyDoesNotExist(t, ok)
+		assertSlicesEqual(t, set.SMembers("users"), []interface{}{1, 2})
+		assertKeyExists(t, set.SKeyExists("users"))
+	})
+
+	t.Run("Existing Members That Are Not Accepted", func(t *testing.T) {
+		// Test declaring keys holding a member that is not an int, or an int out of range.
+		// It ensures that an error is returned and the keys are left unchanged.
+		set := New()
+		set.SAdd("mixed", 1, "member1")
+		set.SAdd("negative", 1, -1)
+
+		assertErrorIs(t, set.SDeclareInt("mixed"), ErrTypeMismatch)
+		assertErrorIs(t, set.SDeclareInt("negative"), ErrIntSetRange)
+		assertCountEqual(t, set.SAdd("mixed", "member2"), 1)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("negative"), []interface{}{1, -1}, "Members")
+	})
+
+	t.Run("Mixed Keys", func(t *testing.T) {
+		// Test combining an int-typed key with a generic key.
+		// It ensures that the members of the bitset take part in the result.
+		set := New()
+		if err := set.SDeclareInt("ints"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		set.SAdd("ints", 1, 2, 3)
+		set.SAdd("generic", 2, 3, "member1")
+
+		assertIntsEqual(t, sortedInts(set.SInter("ints", "generic")), []int{2, 3}, "Inter")
+		assertIntsEqual(t, sortedInts(set.SDiff("ints", "generic")), []int{1}, "Diff")
+		assertSlicesEqualIgnoreOrder(t, set.SUnion("ints", "generic"), []interface{}{1, 2, 3, "member1"}, "Union")
+		assertCountEqual(t, set.SInterStore("stored", "generic", "ints"), 2)
+	})
+
+	t.Run("Clear Keeps the Declaration", func(t *testing.T) {
+		// Test clearing an int-typed key and adding to it again.
+		// It ensures that its members are removed and it stays int-typed.
+		set := New()
+		if err := set.SDeclareInt("users"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		set.SAdd("users", 1, 2)
+		set.SClear("users")
+
+		assertKeyDoesNotExist(t, set.SKeyExists("users"))
+		assertCountEqual(t, set.SCard("users"), 0)
+		assertCountEqual(t, set.SAdd("users", "member1", 3), 1)
+		assertSlicesEqual(t, set.SMembers("users"), []interface{}{3})
+	})
+
+	t.Run("Observers See Changes", func(t *testing.T) {
+		// Test adding and removing members of an int-typed key with observers registered.
+		// It ensures that each member added or removed is reported.
+		set := New()
+		var added, removed []interface{}
+		set.OnAdd(func(key string, member interface{}) { added = append(added, member) })
+		set.OnRemove(func(key string, member interface{}) { removed = append(removed, member) })
+		if err := set.SDeclareInt("users"); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		set.SAdd("users", 1, 2, 2)
+		set.SRem("users", 2, 5)
+
+		assertSlicesEqual(t, added, []interface{}{1, 2})
+		assertSlicesEqual(t, removed, []interface{}{2})
+	})
+}
+
+func BenchmarkIntSet_DenseRange(b *testing.B) {
+	const size = 100000
+
+	b.Run("IntSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := mustIntSet(b)
+			for n := 0; n < size; n++ {
+				if _, err := s.Add(n); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Set", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := New()
+			for n := 0; n < size; n++ {
+				s.SAdd("ids", n)
+			}
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package jellyset
+
+import "iter"
+
+// SFilterIter returns an iterator lazily yielding the members of the set associated with the given key
+// that satisfy pred, without materializing the filtered members in a slice. It is meant to be used with
+// range-over-func, and breaking out of the loop stops the iteration early.
+// If the key does not exist, the iterator yields nothing.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - pred: 	The predicate members must satisfy to be yielded.
+//
+// Returns:
+//   - An iterator over the members satisfying pred.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 2, 3, 4)
+//	for member := range set.SFilterIter("numbers", func(item interface{}) bool { return item.(int)%2 == 0 }) {
+//		fmt.Println(member)
+//	}
+//
+// In this example, the even members 2 and 4 are printed, in no particular order.
+func (s *Set) SFilterIter(key string, pred func(item interface{}) bool) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		for item := range s.get(key) {
+			if pred(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SUnionIter returns an iterator yielding each distinct member of the sets associated with the given keys
+// exactly once. Members already yielded are tracked to skip duplicates across keys, but the union itself is
+// never materialized in a slice, and breaking out of the loop stops the iteration early.
+// Non-existent keys contribute no members.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be combined in the union.
+//
+// Returns:
+//   - An iterator over the union of the sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	for member := range set.SUnionIter("set1", "set2") {
+//		fmt.Println(member)
+//	}
+//
+// In this example, "member1," "member2" and "member3" are each printed once.
+func (s *Set) SUnionIter(keys ...string) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		seen := newSet()
+
+		for _, key := range keys {
+			for item := range s.get(key) {
+				if seen.has(item) {
+					continue
+				}
+
+				seen.add(item)
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SIter returns an iterator yielding each member of the set associated with the given key, in no particular
+// order, to be used with range-over-func. Breaking out of the loop stops the iteration early.
+// If the key does not exist, the iterator yields nothing.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - An iterator over the members of the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	for member := range set.SIter("myset") {
+//		fmt.Println(member)
+//	}
+//
+// In this example, "member1" and "member2" are printed, in no particular order.
+func (s *Set) SIter(key string) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		s.get(key).foreach(yield)
+	}
+}
+
+// SIterAll returns an iterator yielding every (key, member) pair of the Set, across every set, in no particular
+// order. Breaking out of the loop stops the iteration early.
+//
+// Returns:
+//   - An iterator over the keys of the Set paired with each member of their set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2", "member3")
+//	for key, member := range set.SIterAll() {
+//		fmt.Println(key, member)
+//	}
+//
+// In this example, the pairs (set1, member1), (set2, member2) and (set2, member3) are printed.
+func (s *Set) SIterAll() iter.Seq2[string, interface{}] {
+	return func(yield func(string, interface{}) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		for key, set := range s.records {
+			if s.expired(key) {
+				continue
+			}
+
+			for item := range set {
+				if !yield(key, item) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package jellyset
+
+import "testing"
+
+func TestSet_SFilterIter(t *testing.T) {
+	set := New()
+	set.SAdd("numbers", 1, 2, 3, 4, 5, 6)
+	isEven := func(item interface{}) bool { return item.(int)%2 == 0 }
+
+	t.Run("Yield All Matches", func(t *testing.T) {
+		// Test consuming the iterator fully.
+		// It ensures that exactly the members satisfying the predicate are yielded.
+		var result []interface{}
+		for member := range set.SFilterIter("numbers", isEven) {
+			result = append(result, member)
+		}
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{2, 4, 6}, "Yield All Matches")
+	})
+
+	t.Run("Break After First Match", func(t *testing.T) {
+		// Test breaking out of the loop after the first match.
+		// It ensures that the iteration stops and the predicate is not evaluated further.
+		calls, yielded := 0, 0
+		pred := func(item interface{}) bool {
+			calls++
+			return true
+		}
+		for range set.SFilterIter("numbers", pred) {
+			yielded++
+			break
+		}
+		assertCountEqual(t, yielded, 1)
+		assertCountEqual(t, calls, 1)
+	})
+
+	t.Run("Predicate Matching None", func(t *testing.T) {
+		// Test a predicate that matches no member.
+		// It ensures that nothing is yielded.
+		for member := range set.SFilterIter("numbers", func(item interface{}) bool { return false }) {
+			t.Errorf("Expected no members to be yielded, but got %v", member)
+		}
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test iterating over a non-existent set.
+		// It ensures that nothing is yielded.
+		for member := range set.SFilterIter("nonexistent", isEven) {
+			t.Errorf("Expected no members to be yielded, but got %v", member)
+		}
+	})
+}
+
+func TestSet_SUnionIter(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "b", "c", "d")
+	set.SAdd("set3", "d", "e")
+
+	t.Run("Yield Each Distinct Member Once", func(t *testing.T) {
+		// Test consuming the iterator over overlapping sets.
+		// It ensures that every distinct member is yielded exactly once.
+		var result []interface{}
+		for member := range set.SUnionIter("set1", "set2", "set3", "nonexistent") {
+			result = append(result, member)
+		}
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c", "d", "e"}, "Yield Each Distinct Member Once")
+		assertSlicesEqualIgnoreOrder(t, result, set.SUnion("set1", "set2", "set3"), "Yield Each Distinct Member Once")
+	})
+
+	t.Run("Break Early", func(t *testing.T) {
+		// Test stopping the iteration after two members, calling the iterator directly with a counting yield.
+		// It ensures that yield is not called again once it has returned false.
+		calls := 0
+		set.SUnionIter("set1", "set2", "set3")(func(interface{}) bool {
+			calls++
+			return calls < 2
+		})
+		assertCountEqual(t, calls, 2)
+	})
+
+	t.Run("Non-Existent Sets", func(t *testing.T) {
+		// Test iterating over the union of non-existent sets.
+		// It ensures that nothing is yielded.
+		for member := range set.SUnionIter("nonexistent1", "nonexistent2") {
+			t.Errorf("Expected no members to be yielded, but got %v", member)
+		}
+	})
+}
+
+func TestSet_SIter(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Yield All Members", func(t *testing.T) {
+		// Test consuming the iterator fully.
+		// It ensures that every member is yielded exactly once.
+		var result []interface{}
+		for member := range set.SIter("myset") {
+			result = append(result, member)
+		}
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Yield All Members")
+	})
+
+	t.Run("Break After First Member", func(t *testing.T) {
+		// Test breaking out of the loop after the first member.
+		// It ensures that the iteration stops without panicking and yields a member of the set.
+		yielded := 0
+		for member := range set.SIter("myset") {
+			yielded++
+			assertKeyExists(t, set.SIsMember("myset", member))
+			break
+		}
+		assertCountEqual(t, yielded, 1)
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test iterating over a non-existent set.
+		// It ensures that nothing is yielded.
+		for member := range set.SIter("nonexistent") {
+			t.Errorf("Expected no members to be yielded, but got %v", member)
+		}
+	})
+}
+
+func TestSet_SIterAll(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b")
+	set.SAdd("set2", "b", "c")
+
+	t.Run("Yield All Pairs", func(t *testing.T) {
+		// Test consuming the iterator fully.
+		// It ensures that every (key, member) pair is yielded exactly once.
+		var result []interface{}
+		for key, member := range set.SIterAll() {
+			result = append(result, key+"="+member.(string))
+		}
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"set1=a", "set1=b", "set2=b", "set2=c"}, "Yield All Pairs")
+	})
+
+	t.Run("Break After First Pair", func(t *testing.T) {
+		// Test breaking out of the loop after the first pair.
+		// It ensures that the iteration stops without panicking and yields a pair of the Set.
+		yielded := 0
+		for key, member := range set.SIterAll() {
+			yielded++
+			assertKeyExists(t, set.SIsMember(key, member))
+			break
+		}
+		assertCountEqual(t, yielded, 1)
+	})
+
+	t.Run("Empty Set", func(t *testing.T) {
+		// Test iterating over a Set holding no keys.
+		// It ensures that nothing is yielded.
+		for key, member := range New().SIterAll() {
+			t.Errorf("Expected no pairs to be yielded, but got %s=%v", key, member)
+		}
+	})
+}
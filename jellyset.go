@@ -1,7 +1,18 @@
 // Package jellyset  provides a Redis-like Set data structure.
 package jellyset
 
-import "math"
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // keyExists is a placeholder to not write struct{}{} everywhere.
 var keyExists = struct{}{}
@@ -12,14 +23,169 @@ type set map[interface{}]struct{}
 
 // Set represents the high-level interface for interacting with sets.
 // It encapsulates multiple sets, each associated with a unique key.
+// A Set is safe for concurrent use by multiple goroutines.
+//
+// The functions passed to the methods of a Set, such as predicates, comparators and the loop bodies of its
+// iterators, are called while the Set is locked unless their method states otherwise. They must not call any
+// method of the Set, not even one that only reads it: a read lock cannot be acquired again while a writer is
+// waiting, so the call would deadlock.
 type Set struct {
+	mu      sync.RWMutex
 	records map[string]set
+
+	// expires holds the expiration time of the keys set with SExpire, as reported by now.
+	expires map[string]time.Time
+	now     func() time.Time
+
+	// expiryOrder holds the expirations in expires by time, for NextExpiry. It may also hold entries that no longer
+	// match expires, which are skipped and eventually discarded.
+	expiryOrder expiryHeap
+
+	// maxCards holds the maximum cardinality of the keys capped with SSetMaxCard.
+	maxCards map[string]int
+
+	// ints holds the bitsets of the keys declared int-typed with SDeclareInt, apart from records.
+	ints map[string]*IntSet
+
+	// rngMu guards rng, which is used under the read lock by several goroutines at once.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	// seeded tells whether rng was chosen by the caller, in which case draws must be reproducible.
+	// It is guarded by rngMu.
+	seeded bool
+
+	// obsMu guards the observers registered with OnAdd and OnRemove. observed tells whether there are any,
+	// so that mutations are only queued in pending, under the write lock, when someone listens to them.
+	obsMu    sync.Mutex
+	onAdd    []func(key string, member interface{})
+	onRemove []func(key string, member interface{})
+	observed atomic.Bool
+	pending  []mutation
+
+	// onExpire holds the callbacks registered with OnExpire, also guarded by obsMu. expiring tells whether there
+	// are any, so that expired keys are only queued in expiries, under the write lock, when someone listens to them.
+	onExpire []func(key string, members []interface{})
+	expiring atomic.Bool
+	expiries []expiry
+
+	// stats holds the operation counters enabled by WithStats, or is nil when they are not kept.
+	stats *opStats
+
+	// order holds, when WithInsertionOrder is used, the position at which each member was added to its key,
+	// taken from orderSeq. It is nil when insertion order is not recorded.
+	order    map[string]map[interface{}]uint64
+	orderSeq uint64
+
+	// interWatches holds the keys of the sources of the intersections kept up to date by SInterWatch,
+	// by the key they are stored under.
+	interWatches map[string][]string
+
+	// version counts the acquisitions of the write lock, so that a cached ordering can tell whether the records
+	// may have changed since it was computed.
+	version uint64
+
+	// scanMu guards scanOrders, which holds the members of the keys scanned by SScan and SScanMatch in scan order,
+	// as computed at scanVersion. It is needed because scans only hold the read lock.
+	scanMu      sync.Mutex
+	scanOrders  map[string][]interface{}
+	scanVersion uint64
+}
+
+// Option configures a Set created with New.
+type Option func(*Set)
+
+// WithRandSeed seeds the source of randomness used by SPop and SRandMember, so that their results
+// are reproducible for a given sequence of operations. By default, the source is seeded with the current time.
+func WithRandSeed(seed int64) Option {
+	return func(s *Set) {
+		s.rng = rand.New(rand.NewSource(seed))
+		s.seeded = true
+	}
+}
+
+// WithRandSource sets the source of randomness used by SPop, SRandMember and the other randomized operations,
+// so that callers control it entirely, for example to share one seeded source between test fixtures.
+// The Set guards r with its own lock, so r must not be used elsewhere while the Set may use it.
+// A nil r keeps the default source, seeded with the current time.
+func WithRandSource(r *rand.Rand) Option {
+	return func(s *Set) {
+		s.useRandSource(r)
+	}
+}
+
+// WithClock sets the function used to read the current time when expiring keys, which defaults to time.Now.
+// It is mainly useful to control the passing of time in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Set) {
+		s.now = now
+	}
+}
+
+// New creates and returns a new empty Set configured with the given options.
+func New(opts ...Option) *Set {
+	s := &Set{
+		records:      make(map[string]set),
+		expires:      make(map[string]time.Time),
+		now:          time.Now,
+		rng:          newDefaultRand(),
+		maxCards:     make(map[string]int),
+		ints:         make(map[string]*IntSet),
+		interWatches: make(map[string][]string),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// NewWith creates and returns a new Set holding a single set, associated with key and seeded with the given members.
+func NewWith(key string, members []interface{}) *Set {
+	s := New()
+	s.SAddSlice(key, members)
+	return s
+}
+
+// SetRandSource replaces the source of randomness of the Set, like WithRandSource, once it is in use.
+// The next randomized operation draws from r, so reseeding a Set makes its following results reproducible.
+// A nil r restores a default source, seeded with the current time.
+//
+// Parameters:
+//   - r: 		The source of randomness to draw from. It must not be used elsewhere while the Set may use it.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	set.SetRandSource(rand.New(rand.NewSource(42)))
+//	popped := set.SPop("myset", 1)
+//
+// In this example, 'popped' holds the same member every time the example runs.
+func (s *Set) SetRandSource(r *rand.Rand) {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+
+	s.useRandSource(r)
 }
 
-func New() *Set {
-	return &Set{
-		records: make(map[string]set),
+// useRandSource makes r the source of randomness of the Set, or restores a default source if r is nil.
+// The caller must hold rngMu, unless the Set is not in use yet.
+func (s *Set) useRandSource(r *rand.Rand) {
+	if r == nil {
+		s.rng = newDefaultRand()
+		s.seeded = false
+		return
 	}
+
+	s.rng = r
+	s.seeded = true
+}
+
+// newDefaultRand returns the source of randomness used when none is chosen, seeded with the current time.
+func newDefaultRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
 // newSet creates and returns a new empty set.
@@ -27,9 +193,19 @@ func newSet() set {
 	return make(map[interface{}]struct{})
 }
 
+// setOf creates and returns a new set holding the given items.
+func setOf(items ...interface{}) set {
+	s := make(set, len(items))
+	s.add(items...)
+	return s
+}
+
 // SAdd adds one or more members to the set associated with the provided key. If the key does not exist,
 // it creates a new set and adds the specified members to it. This function returns the number of elements
-// that were successfully added to the set.
+// that were successfully added to the set. If the key is capped with SSetMaxCard, the members that would
+// grow the set beyond its cap are left out. Members that cannot be used as map keys, such as slices or maps,
+// are skipped and not counted instead of panicking; SAddChecked reports them. If the key is int-typed with
+// SDeclareInt, the members are added to its bitset, and those that are not ints it accepts are skipped.
 //
 // Parameters:
 //   - key: 	The key associated with the set.
@@ -45,573 +221,3312 @@ func newSet() set {
 // In this example, three members are added to the set "myset," and the function returns the count of elements added.
 
 func (s *Set) SAdd(key string, members ...interface{}) int {
-	if !s.exists(key) {
-		s.records[key] = newSet()
+	var added int
+	mutations := s.mutate(func() {
+		if ints, ok := s.ints[key]; ok {
+			added = s.addInts(key, ints, members...)
+			return
+		}
+		added = s.addMembers(key, members...)
+	})
+
+	if s.stats != nil {
+		s.stats.sAdd.Add(1)
 	}
 
-	added := 0
-	set := s.records[key]
+	s.notify(mutations)
+	return added
+}
 
-	for _, member := range members {
-		if _, exists := set[member]; !exists {
-			set[member] = keyExists
-			added++
-		}
-	}
+// SAddSlice adds the members held in a slice to the set associated with the provided key, like SAdd,
+// reading them directly from the slice. If the key does not exist, a new set is created.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: The slice holding the members to be added to the set.
+//
+// Returns:
+//   - The number of elements added to the set.
+//
+// Example:
+//
+//	set := New()
+//	count := set.SAddSlice("myset", []interface{}{"member1", "member2", "member1"})
+//
+// In this example, two distinct members are added to the set "myset," and 'count' will be 2.
+func (s *Set) SAddSlice(key string, members []interface{}) int {
+	var added int
+	mutations := s.mutate(func() {
+		added = s.addMembers(key, members...)
+	})
 
+	s.notify(mutations)
 	return added
 }
 
-// SPop removes and returns one or more random members from the set associated with the given key.
-// If the key does not exist or the count is less than or equal to 0, it returns an empty slice.
+// SAddChecked adds one or more members to the set associated with the provided key, like SAdd, and also returns
+// the members that were rejected because they cannot be used as members, such as slices, maps, functions, or
+// structs and arrays holding them. The rejected members are returned in the order they were given.
 //
 // Parameters:
 //   - key: 	The key associated with the set.
-//   - count: 	The number of random members to pop from the set. If count is 0 or negative, no members are popped.
+//   - members: One or more members to be added to the set.
 //
 // Returns:
-//   - A slice containing the popped members. If the set is empty or the count is zero, an empty slice is returned.
+//   - The number of elements added to the set.
+//   - The rejected members, or nil if every member was accepted.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3", "member4", "member5")
-//	popped := set.SPop("myset", 3)
+//	added, rejected := set.SAddChecked("myset", "member1", []int{1, 2})
 //
-// In this example, three random members are removed and returned from the set "myset," and they are stored in the 'popped' slice.
-func (s *Set) SPop(key string, count int) []interface{} {
-	if !s.exists(key) || count <= 0 {
-		return []interface{}{}
+// In this example, 'added' will be 1 and 'rejected' holds the slice []int{1, 2}.
+func (s *Set) SAddChecked(key string, members ...interface{}) (added int, rejected []interface{}) {
+	accepted := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		if isComparable(member) {
+			accepted = append(accepted, member)
+		} else {
+			rejected = append(rejected, member)
+		}
 	}
 
-	set := s.records[key]
-	members := make([]interface{}, count)
+	mutations := s.mutate(func() {
+		added = s.addMembers(key, accepted...)
+	})
 
-	i := 0
-	for k := range set {
-		members[i] = k
-		delete(set, k)
-		i++
+	s.notify(mutations)
+	return added, rejected
+}
 
-		if i == count {
-			break
+// SAddReport adds one or more members to the set associated with the provided key, like SAdd, and returns the
+// members that were not in the set yet, in the order they were given. A member given several times is reported
+// once, and members left out by the cap of the key or because they cannot be members are not reported.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: One or more members to be added to the set.
+//
+// Returns:
+//   - A slice containing the members added to the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1")
+//	added := set.SAddReport("myset", "member2", "member1", "member3", "member2")
+//
+// In this example, 'added' will be ["member2", "member3"].
+func (s *Set) SAddReport(key string, members ...interface{}) []interface{} {
+	added := []interface{}{}
+	mutations := s.mutate(func() {
+		for _, member := range members {
+			if s.addMembers(key, member) == 1 {
+				added = append(added, member)
+			}
 		}
-	}
+	})
 
-	return members
+	s.notify(mutations)
+	return added
 }
 
-// SRandMember returns one or more random members from the set associated with the given key.
-// If the key does not exist or the count is less than 1, it returns an empty slice.
+// SAddNX adds one or more members to the set associated with the given key only if the key does not exist yet,
+// so that a key can be initialized exactly once even when several goroutines race to do it. If the key already
+// exists, nothing is changed. A call that adds no member, for instance because all of them cannot be used as map
+// keys, does not create the key and returns false.
 //
 // Parameters:
 //   - key: 	The key associated with the set.
-//   - count: 	The number of random members to retrieve from the set. If count is less than 1, no members are retrieved.
+//   - members: One or more members to be added to the set.
 //
 // Returns:
-//   - A slice containing the random members. If the set is empty or the count is less than 1, an empty slice is returned.
+//   - true if this call created the key, false otherwise.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3", "member4", "member5")
-//	randomMembers := set.SRandMember("myset", 3)
+//	created := set.SAddNX("config", "member1")
+//	createdAgain := set.SAddNX("config", "member2")
 //
-// In this example, three random members are retrieved from the set "myset," and they are stored in the 'randomMembers' slice.
-func (s *Set) SRandMember(key string, count int) []interface{} {
-	if !s.exists(key) || count < 1 {
-		return []interface{}{}
-	}
+// In this example, 'created' will be true, 'createdAgain' will be false, and "config" only holds "member1."
+func (s *Set) SAddNX(key string, members ...interface{}) bool {
+	added := 0
+	mutations := s.mutate(func() {
+		if !s.exists(key) {
+			added = s.addMembers(key, members...)
+		}
+	})
 
-	set := s.records[key]
-	members := make([]interface{}, count)
+	s.notify(mutations)
+	return added > 0
+}
 
-	if count > 0 {
-		i := 0
-		for k := range set {
-			members[i] = k
-			i++
+// addMembers adds the members to the set associated with the key, creating it if needed, up to the maximum
+// cardinality of the key, and returns the number of members added. The caller must hold the write lock.
+func (s *Set) addMembers(key string, members ...interface{}) int {
+	added, _ := s.addBounded(key, members...)
+	return added
+}
 
-			if i == count {
-				break
-			}
+// addBounded adds the members to the set associated with the key, creating it if needed, until the set reaches
+// the maximum cardinality of the key. Members that are not comparable are skipped. It returns the number of
+// members added, and whether a member was left out because of the cap. If no member ends up in the set, the key
+// is not left behind. The caller must hold the write lock.
+func (s *Set) addBounded(key string, members ...interface{}) (int, bool) {
+	if !s.exists(key) {
+		s.put(key, newSet())
+	}
+	defer s.deleteIfEmpty(key)
+
+	added := 0
+	set := s.get(key)
+	maxCard, capped := s.maxCards[key]
+
+	for _, member := range members {
+		if !isComparable(member) {
+			continue
 		}
-	} else {
-		count = -count
-		for i := 0; i < count; i++ {
-			randomVal := randomElement(set)
-			if randomVal == nil {
-				break
+
+		if _, exists := set[member]; !exists {
+			if capped && set.size() >= maxCard {
+				return added, true
 			}
-			members[i] = randomVal
+
+			s.insert(key, set, member)
+			added++
 		}
 	}
-	return members
+
+	return added, false
 }
 
-// SIsMember checks if the specified member exists in the set associated with the given key.
-// If the key does not exist, it returns false.
+// SPop removes and returns one or more random members from the set associated with the given key.
+// If the set becomes empty, the key is deleted.
+// Every member has the same probability of being popped.
+// If the count exceeds the size of the set, every member is popped, so the returned slice never holds more
+// members than the set had. If the key does not exist or the count is less than or equal to 0, it returns an empty slice.
 //
 // Parameters:
 //   - key: 	The key associated with the set.
-//   - member: 	The member to check for existence in the set.
+//   - count: 	The number of random members to pop from the set. If count is 0 or negative, no members are popped.
 //
 // Returns:
-//   - true if the member exists in the set, false otherwise.
+//   - A slice containing the popped members. If the set is empty or the count is zero, an empty slice is returned.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3")
-//	exists := set.SIsMember("myset", "member2")
+//	set.SAdd("myset", "member1", "member2", "member3", "member4", "member5")
+//	popped := set.SPop("myset", 3)
 //
-// In this example, it checks if "member2" exists in the set "myset," and 'exists' will be true.
-func (s *Set) SIsMember(key string, member interface{}) bool {
-	if !s.exists(key) {
-		return false
-	}
+// In this example, three random members are removed and returned from the set "myset," and they are stored in the 'popped' slice.
+func (s *Set) SPop(key string, count int) []interface{} {
+	var popped []interface{}
+	mutations := s.mutate(func() {
+		popped = s.pop(key, count)
+	})
 
-	set := s.records[key]
-	_, exists := set[member]
+	if s.stats != nil {
+		s.stats.sPop.Add(1)
+	}
 
-	return exists
+	s.notify(mutations)
+	return popped
 }
 
-// SRem removes the specified member from the set associated with the given key.
-// If the key does not exist or the member is not in the set, it returns false.
+// STrim removes random members from the set associated with the given key until it holds at most maxSize members,
+// to evict a bounded cache down to its size. The members are chosen like those of SPop, so trimming is reproducible
+// on a Set created with WithRandSeed or WithRandSource. A maxSize of 0 or less empties the set and deletes the key.
 //
 // Parameters:
-//   - key: 	The key associated with the set.
-//   - member: 	The member to remove from the set.
+//   - key: 		The key associated with the set.
+//   - maxSize: 	The maximum number of members left in the set.
 //
 // Returns:
-//   - true if the member was successfully removed, false otherwise.
+//   - The number of members removed, 0 if the set already holds at most maxSize members or does not exist.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3")
-//	removed := set.SRem("myset", "member2")
-//
-// In this example, it removes "member2" from the set "myset," and 'removed' will be true.
-func (s *Set) SRem(key string, member interface{}) bool {
-	if !s.exists(key) {
-		return false
-	}
+//	set.SAdd("cache", "member1", "member2", "member3", "member4", "member5")
+//	removed := set.STrim("cache", 2)
+//
+// In this example, 'removed' will be 3, and "cache" keeps 2 random members.
+func (s *Set) STrim(key string, maxSize int) int {
+	var removed []interface{}
+	mutations := s.mutate(func() {
+		removed = s.pop(key, s.get(key).size()-max(maxSize, 0))
+	})
+
+	s.notify(mutations)
+	return len(removed)
+}
 
-	set := s.records[key]
+// pop removes and returns up to count members from the set associated with the key.
+// The caller must hold the write lock.
+func (s *Set) pop(key string, count int) []interface{} {
+	if !s.exists(key) || count <= 0 {
+		return []interface{}{}
+	}
 
-	if _, exists := set[member]; exists {
-		delete(set, member)
-		return true
+	set := s.get(key)
+	members := s.sample(set, count)
+	for _, member := range members {
+		s.evict(key, set, member)
 	}
+	s.deleteIfEmpty(key)
 
-	return false
+	return members
 }
 
-// SMove moves a member from the source set to the destination set.
-// If the source set does not exist or the member is not in the source set, it returns false.
-// If the destination set does not exist, it creates a new set.
+// RandMemberMaxRepeats is the largest number of possibly repeated members SRandMember returns for a negative count,
+// so that a count of very large magnitude cannot exhaust memory.
+const RandMemberMaxRepeats = 1 << 20
+
+// SRandMember returns one or more random members from the set associated with the given key, following the
+// semantics of Redis SRANDMEMBER. With a positive count, it returns up to count distinct members. With a negative
+// count, it returns exactly -count members which may repeat, even if -count exceeds the size of the set, but no more
+// than RandMemberMaxRepeats members.
+// Every member has the same probability of being returned.
+// If the key does not exist, the set is empty or the count is 0, it returns an empty slice.
 //
 // Parameters:
-//   - src: 	The key associated with the source set.
-//   - dest: 	The key associated with the destination set.
-//   - member: 	The member to move from the source set to the destination set.
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to retrieve from the set. A negative count allows repeated members.
 //
 // Returns:
-//   - true if the member was successfully moved, false otherwise.
+//   - A slice containing the random members. If the set is empty or the count is 0, an empty slice is returned.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("sourceSet", "member1", "member2")
-//	set.SMove("sourceSet", "destSet", "member2")
+//	set.SAdd("myset", "member1", "member2", "member3", "member4", "member5")
+//	randomMembers := set.SRandMember("myset", 3)
 //
-// In this example, it moves "member2" from the "sourceSet" to the "destSet," and it returns true.
-func (s *Set) SMove(src, dest string, member interface{}) bool {
-	if !s.fieldExists(src, member) {
-		return false
+// In this example, three random members are retrieved from the set "myset," and they are stored in the 'randomMembers' slice.
+func (s *Set) SRandMember(key string, count int) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.randMember(key, count)
+}
+
+// randMember returns count random members of the set associated with the key, following SRandMember.
+// The caller must hold the read lock.
+func (s *Set) randMember(key string, count int) []interface{} {
+	if !s.exists(key) || count == 0 || s.get(key).size() == 0 {
+		return []interface{}{}
 	}
 
-	if !s.exists(dest) {
-		s.records[dest] = make(set)
+	set := s.get(key)
+	if count > 0 {
+		return s.sample(set, count)
+	}
+
+	if count < -RandMemberMaxRepeats {
+		count = -RandMemberMaxRepeats
 	}
 
-	srcSet := s.records[src]
-	destSet := s.records[dest]
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
 
-	srcSet.remove(member)
-	destSet.add(member)
+	candidates := s.candidates(set)
+	members := make([]interface{}, -count)
 
-	return true
+	for i := range members {
+		members[i] = candidates[s.rng.Intn(len(candidates))]
+	}
+
+	return members
 }
 
-// SCard returns the number of elements in the set associated with the given key.
-// If the key does not exist, it returns 0, indicating an empty set.
+// SRandMemberDistinct returns min(count, SCard(key)) distinct members of the set associated with the given key,
+// chosen uniformly at random with a partial Fisher-Yates shuffle over the members. It behaves as SRandMember with
+// a positive count, but never switches to sampling with repeats: a count that is 0 or negative returns an empty slice.
 //
 // Parameters:
-//   - key:		The key associated with the set.
+//   - key: 	The key associated with the set.
+//   - count: 	The number of distinct random members to retrieve from the set.
 //
 // Returns:
-//   - The number of elements in the set.
+//   - A slice containing the random members, or an empty slice if the key does not exist or count is less than 1.
 //
 // Example:
 //
 //	set := New()
 //	set.SAdd("myset", "member1", "member2", "member3")
-//	size := set.SCard("myset")
+//	randomMembers := set.SRandMemberDistinct("myset", 5)
 //
-// In this example, it retrieves the size of the set "myset," which contains three members, and 'size' will be 3.
-func (s *Set) SCard(key string) int {
-	if !s.exists(key) {
-		return 0
+// In this example, 'randomMembers' holds the three members of "myset" in a random order.
+func (s *Set) SRandMemberDistinct(key string, count int) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if count < 1 {
+		return []interface{}{}
 	}
 
-	set := s.records[key]
-	return set.size()
+	return s.sample(s.get(key), count)
 }
 
-// SMembers returns a slice containing all the members of the set associated with the given key.
-// If the key does not exist, it returns an empty slice.
+// SSample returns min(k, SCard(key)) distinct members of the set associated with the given key, chosen uniformly
+// at random with reservoir sampling (Algorithm R) over a single pass of the set. Unlike SRandMemberDistinct, it only
+// holds k members at a time instead of a copy of every member, which suits taking a handful of members from a huge
+// set. As it follows the iteration order of the set, its result is not reproducible with WithRandSeed.
 //
 // Parameters:
 //   - key: 	The key associated with the set.
+//   - k: 		The number of distinct random members to retrieve from the set.
 //
 // Returns:
-//   - A slice containing all the members of the set. If the set is empty or the key does not exist, an empty slice is returned.
+//   - A slice containing the random members, or an empty slice if the key does not exist or k is less than 1.
 //
 // Example:
 //
 //	set := New()
 //	set.SAdd("myset", "member1", "member2", "member3")
-//	members := set.SMembers("myset")
+//	sampled := set.SSample("myset", 2)
 //
-// In this example, it retrieves all members from the set "myset," and 'members' will be a slice containing ["member1", "member2", "member3"].
-func (s *Set) SMembers(key string) []interface{} {
-	if !s.exists(key) {
+// In this example, 'sampled' holds two distinct members of "myset," each pair being equally likely.
+func (s *Set) SSample(key string, k int) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	if k < 1 || set.size() == 0 {
 		return []interface{}{}
 	}
 
-	set := s.records[key]
-	members := make([]interface{}, 0, len(set))
+	reservoir := make([]interface{}, 0, min(k, set.size()))
+
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+
+	seen := 0
 	for item := range set {
-		members = append(members, item)
+		seen++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, item)
+			continue
+		}
+
+		if j := s.rng.Intn(seen); j < k {
+			reservoir[j] = item
+		}
 	}
 
-	return members
+	return reservoir
 }
 
-// SUnion returns a new set that is the union of multiple sets. It combines all elements
-// present in all the sets provided as arguments.
+// SIsMember checks if the specified member exists in the set associated with the given key.
+// If the key does not exist, it returns false.
 //
 // Parameters:
-//   - keys: 	The keys associated with the sets to be combined in the union.
+//   - key: 	The key associated with the set.
+//   - member: 	The member to check for existence in the set.
 //
 // Returns:
-//   - A slice containing the union of elements from all the specified sets.
+//   - true if the member exists in the set, false otherwise.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	result := set.SUnion("set1", "set2")
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	exists := set.SIsMember("myset", "member2")
 //
-// In this example, the union of "set1" and "set2" is computed, and 'result' contains all unique elements from both sets.
-// SUnion returns a new set that is the union of multiple sets. It combines all elements
-// present in all the sets provided as arguments.
-func (s *Set) SUnion(keys ...string) []interface{} {
-	uniqueElements := newSet()
+// In this example, it checks if "member2" exists in the set "myset," and 'exists' will be true.
+func (s *Set) SIsMember(key string, member interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	for _, key := range keys {
-		if set, exists := s.records[key]; exists {
-			// Iterate over elements in the current set and add them to the uniqueElements map.
-			for item := range set {
-				uniqueElements[item] = struct{}{}
-			}
-		}
+	if ints, ok := s.ints[key]; ok {
+		n, ok := member.(int)
+		return ok && ints.Contains(n)
 	}
 
-	result := make([]interface{}, 0, len(uniqueElements))
-	for item := range uniqueElements {
-		result = append(result, item)
+	if !s.exists(key) {
+		return false
 	}
 
-	return result
+	set := s.get(key)
+	_, exists := set[member]
+
+	return exists
 }
 
-// SUnionStore computes the union of multiple sets and stores the result in a new set. If the destination
-// set (storeKey) already exists, it will be overridden with the new union results.
+// SMIsMember checks the membership of several members in the set associated with the given key at once,
+// following Redis SMISMEMBER. The result is aligned positionally with the members passed in.
+// If the key does not exist, every member is reported as absent.
 //
 // Parameters:
-//   - storeKey: 	The key associated with the destination set where the result will be stored.
-//   - keys: 		The keys associated with the sets to be combined in the union.
+//   - key: 	The key associated with the set.
+//   - members: The members to check for existence in the set.
 //
 // Returns:
-//   - The number of elements in the resulting union set.
+//   - A slice of booleans where the value at index i tells whether members[i] exists in the set.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	count := set.SUnionStore("unionSet", "set1", "set2")
+//	set.SAdd("myset", "member1", "member2")
+//	exists := set.SMIsMember("myset", "member1", "member3", "member2")
 //
-// In this example, the union of "set1" and "set2" is computed and stored in "unionSet," and 'count' contains the number of elements in the resulting union set.
-func (s *Set) SUnionStore(storeKey string, keys ...string) int {
-	if s.exists(storeKey) {
-		delete(s.records, storeKey)
-	}
+// In this example, 'exists' will be [true false true].
+func (s *Set) SMIsMember(key string, members ...interface{}) []bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	exists := make([]bool, len(members))
 
-	union := s.SUnion(keys...)
-	for _, unionKey := range union {
-		s.SAdd(storeKey, unionKey)
+	for i, member := range members {
+		_, exists[i] = set[member]
 	}
 
-	return len(union)
+	return exists
+}
+
+// SContainsAny checks if at least one of the members exists in the set associated with the given key.
+// It stops at the first member found, so members after it are not looked up.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: The candidate members.
+//
+// Returns:
+//   - true if any of the members exists in the set, false if none does, the key does not exist, or no member is given.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	found := set.SContainsAny("myset", "member3", "member2")
+//
+// In this example, "member2" is in the set, and 'found' will be true.
+func (s *Set) SContainsAny(key string, members ...interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	for _, member := range members {
+		if set.has(member) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SRem removes one or more members from the set associated with the given key, and returns the number of
+// members that were actually removed. Members that are not in the set, or a key that does not exist,
+// contribute nothing to the count. If the set becomes empty, the key is deleted.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: The members to remove from the set.
+//
+// Returns:
+//   - The number of members removed from the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	removed := set.SRem("myset", "member2", "member3", "member4")
+//
+// In this example, it removes "member2" and "member3" from the set "myset," and 'removed' will be 2.
+func (s *Set) SRem(key string, members ...interface{}) int {
+	var removed int
+	mutations := s.mutate(func() {
+		if ints, ok := s.ints[key]; ok {
+			removed = s.remInts(key, ints, members...)
+			return
+		}
+		removed = s.remMembers(key, members...)
+	})
+
+	if s.stats != nil {
+		s.stats.sRem.Add(1)
+	}
+
+	s.notify(mutations)
+	return removed
+}
+
+// insert adds a member that is not in the set associated with the key, and reports the addition to the
+// member counters, the observers, the insertion order and the watched intersections. The caller must hold
+// the write lock.
+func (s *Set) insert(key string, set set, member interface{}) {
+	set[member] = keyExists
+	if s.stats != nil {
+		s.stats.membersAdded.Add(1)
+	}
+	s.record(true, key, member)
+	s.trackOrder(key, member)
+	s.watchAdded(key, member)
+}
+
+// evict removes a member of the set associated with the key, and reports the removal like insert reports
+// an addition. The caller must hold the write lock.
+func (s *Set) evict(key string, set set, member interface{}) {
+	delete(set, member)
+	if s.stats != nil {
+		s.stats.membersRemoved.Add(1)
+	}
+	s.record(false, key, member)
+	s.forgetOrder(key, member)
+	s.watchRemoved(key, member)
+}
+
+// remMembers removes the members from the set associated with the key, deleting it once it is empty,
+// and returns the number of members removed. The caller must hold the write lock.
+func (s *Set) remMembers(key string, members ...interface{}) int {
+	if !s.exists(key) {
+		return 0
+	}
+
+	set := s.get(key)
+	removed := 0
+
+	for _, member := range members {
+		if _, exists := set[member]; exists {
+			s.evict(key, set, member)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		s.deleteIfEmpty(key)
+	}
+
+	return removed
+}
+
+// SMove moves a member from the source set to the destination set.
+// If the source set does not exist or the member is not in the source set, it returns false, as it does for
+// a member that cannot be in any set, such as a slice or a map.
+// If the destination set does not exist, it creates a new set. If the source set becomes empty, its key is deleted.
+//
+// Parameters:
+//   - src: 	The key associated with the source set.
+//   - dest: 	The key associated with the destination set.
+//   - member: 	The member to move from the source set to the destination set.
+//
+// Returns:
+//   - true if the member was successfully moved, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("sourceSet", "member1", "member2")
+//	set.SMove("sourceSet", "destSet", "member2")
+//
+// In this example, it moves "member2" from the "sourceSet" to the "destSet," and it returns true.
+func (s *Set) SMove(src, dest string, member interface{}) bool {
+	var moved bool
+	mutations := s.mutate(func() {
+		moved = s.move(src, dest, member)
+	})
+
+	s.notify(mutations)
+	return moved
+}
+
+// SMoveIfAbsent moves a member from the source set to the destination set, like SMove, unless the destination set
+// already holds it, in which case the member is only removed from the source set. Either way, the member ends up
+// in the destination set only once. If src and dest are the same key, nothing is changed.
+//
+// Parameters:
+//   - src: 	The key associated with the source set.
+//   - dest: 	The key associated with the destination set.
+//   - member: 	The member to move from the source set to the destination set.
+//
+// Returns:
+//   - moved: 			true if the member was added to the destination set.
+//   - removedFromSrc: 	true if the member was removed from the source set, whether or not it was moved.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("sourceSet", "member1", "member2")
+//	set.SAdd("destSet", "member2")
+//	moved, removed := set.SMoveIfAbsent("sourceSet", "destSet", "member2")
+//
+// In this example, "member2" is removed from "sourceSet" without being moved, so 'moved' will be false and
+// 'removed' will be true.
+func (s *Set) SMoveIfAbsent(src, dest string, member interface{}) (moved bool, removedFromSrc bool) {
+	mutations := s.mutate(func() {
+		if src != dest && isComparable(member) && s.fieldExists(src, member) {
+			if s.fieldExists(dest, member) {
+				removedFromSrc = s.remMembers(src, member) > 0
+			} else {
+				moved = s.move(src, dest, member)
+				removedFromSrc = moved
+			}
+		}
+	})
+
+	s.notify(mutations)
+	return moved, removedFromSrc
+}
+
+// move moves the member from the source set to the destination set, as described by SMove.
+// The caller must hold the write lock.
+func (s *Set) move(src, dest string, member interface{}) bool {
+	if !isComparable(member) || !s.fieldExists(src, member) {
+		return false
+	}
+
+	if src == dest {
+		return true
+	}
+
+	if !s.exists(dest) {
+		s.put(dest, newSet())
+	}
+
+	destSet := s.get(dest)
+	s.evict(src, s.get(src), member)
+	if !destSet.has(member) {
+		s.insert(dest, destSet, member)
+	}
+	s.deleteIfEmpty(src)
+
+	return true
+}
+
+// SCard returns the number of elements in the set associated with the given key.
+// If the key does not exist, it returns 0, indicating an empty set.
+//
+// Parameters:
+//   - key:		The key associated with the set.
+//
+// Returns:
+//   - The number of elements in the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	size := set.SCard("myset")
+//
+// In this example, it retrieves the size of the set "myset," which contains three members, and 'size' will be 3.
+func (s *Set) SCard(key string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if ints, ok := s.ints[key]; ok {
+		return ints.Card()
+	}
+
+	if !s.exists(key) {
+		return 0
+	}
+
+	set := s.get(key)
+	return set.size()
+}
+
+// SMembers returns a slice containing all the members of the set associated with the given key.
+// If the key does not exist, it returns an empty slice. If the key is int-typed with SDeclareInt, the members
+// are returned in ascending order.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A slice containing all the members of the set. If the set is empty or the key does not exist, an empty slice is returned.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	members := set.SMembers("myset")
+//
+// In this example, it retrieves all members from the set "myset," and 'members' will be a slice containing ["member1", "member2", "member3"].
+func (s *Set) SMembers(key string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if ints, ok := s.ints[key]; ok {
+		return ints.list()
+	}
+
+	if !s.exists(key) {
+		return []interface{}{}
+	}
+
+	set := s.get(key)
+	members := make([]interface{}, 0, len(set))
+	for item := range set {
+		members = append(members, item)
+	}
+
+	return members
+}
+
+// SMembersPage returns a page of up to limit members of the set associated with the given key, starting at offset.
+// Members are ordered by their formatted value, then by the name of their type, as in SScanStable, so for a set
+// that is not modified, consecutive pages cover every member exactly once. Members sharing both their formatted
+// value and their type have no defined order between them. Each call sorts the whole set; use SScanStable to
+// page through a set that changes between calls.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - offset: 	The position of the first member of the page, starting at 0.
+//   - limit: 	The maximum number of members to return.
+//
+// Returns:
+//   - A slice containing the members of the page, or an empty slice if the key does not exist, offset is negative
+//     or past the last member, or limit is less than 1.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	page := set.SMembersPage("myset", 1, 5)
+//
+// In this example, 'page' will contain "member2" and "member3."
+func (s *Set) SMembersPage(key string, offset, limit int) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	if offset < 0 || offset >= set.size() || limit < 1 {
+		return []interface{}{}
+	}
+
+	members := set.sortedList()
+	if limit >= len(members)-offset {
+		return members[offset:]
+	}
+
+	return members[offset : offset+limit]
+}
+
+// SMembersInto writes all the members of the set associated with the given key into buf, reusing its
+// capacity, and returns the resulting slice. buf is resliced to buf[:0] first, and only grows when its
+// capacity is too small, so callers can pool their buffers across calls instead of allocating with SMembers.
+// If the key does not exist, it returns buf[:0].
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - buf: 	The buffer the members are written into. It may be nil.
+//
+// Returns:
+//   - A slice containing all the members of the set, sharing buf's backing array when it is large enough.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	buf := make([]interface{}, 0, 16)
+//	buf = set.SMembersInto("myset", buf)
+//
+// In this example, 'buf' holds the three members of "myset" without a new allocation.
+func (s *Set) SMembersInto(key string, buf []interface{}) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	buf = buf[:0]
+	for item := range s.get(key) {
+		buf = append(buf, item)
+	}
+
+	return buf
+}
+
+// SUnion returns a new set that is the union of multiple sets. It combines all elements
+// present in all the sets provided as arguments.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be combined in the union.
+//
+// Returns:
+//   - A slice containing the union of elements from all the specified sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	result := set.SUnion("set1", "set2")
+//
+// In this example, the union of "set1" and "set2" is computed, and 'result' contains all unique elements from both sets.
+func (s *Set) SUnion(keys ...string) []interface{} {
+	if s.stats != nil {
+		s.stats.sUnion.Add(1)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if sets, ok := s.intKeys(keys); ok {
+		return unionInts(sets)
+	}
+
+	return s.unionMembers(keys...)
+}
+
+// unionMembers returns the union of the sets associated with the keys as a slice.
+// The caller must hold the read lock.
+func (s *Set) unionMembers(keys ...string) []interface{} {
+	uniqueElements := newSet()
+
+	for _, key := range keys {
+		if set, exists := s.view(key); exists {
+			// Iterate over elements in the current set and add them to the uniqueElements map.
+			for item := range set {
+				uniqueElements[item] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]interface{}, 0, len(uniqueElements))
+	for item := range uniqueElements {
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// SUnionStore computes the union of multiple sets and stores the result in a new set. If the destination
+// set (storeKey) already exists, it will be overridden with the new union results. If the result is empty,
+// storeKey is deleted. The result is computed in full before storeKey is written, so storeKey may also be
+// one of the source keys.
+//
+// Parameters:
+//   - storeKey: 	The key associated with the destination set where the result will be stored.
+//   - keys: 		The keys associated with the sets to be combined in the union.
+//
+// Returns:
+//   - The number of elements in the resulting union set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	count := set.SUnionStore("unionSet", "set1", "set2")
+//
+// In this example, the union of "set1" and "set2" is computed and stored in "unionSet," and 'count' contains the number of elements in the resulting union set.
+func (s *Set) SUnionStore(storeKey string, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	return s.store(storeKey, setOf(s.unionMembers(keys...)...))
+}
+
+// SKeyExists checks if the specified key exists in the Set.
+//
+// Parameters:
+//   - key: 	The key to check for existence.
+//
+// Returns:
+//   - true if the key exists in the Set, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	exists := set.SKeyExists("myset")
+//
+// In this example, it checks if the key "myset" exists in the Set, and 'exists' will be true.
+func (s *Set) SKeyExists(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if ints, ok := s.ints[key]; ok {
+		return ints.Card() > 0
+	}
+
+	return s.exists(key)
+}
+
+// SClear deletes the specified key and its associated set from the records. If the key is int-typed with
+// SDeclareInt, its bitset is emptied and the key stays int-typed.
+//
+// Parameters:
+//   - key: 	The key associated with the set to be cleared.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	set.SClear("myset")
+//
+// In this example, the set associated with the key "myset" is deleted from the records.
+func (s *Set) SClear(key string) {
+	s.lock()
+	defer s.unlock()
+
+	if _, ok := s.ints[key]; ok {
+		s.ints[key] = &IntSet{}
+		return
+	}
+
+	if s.exists(key) {
+		s.drop(key)
+		s.watchCleared(key)
+	}
+}
+
+// SClearMatch deletes every key matching the given Redis-style glob pattern, using the same syntax as
+// SKeysMatch, along with its associated set. It is useful to invalidate a family of keys at once.
+//
+// Parameters:
+//   - pattern: 	The glob pattern of the keys to delete.
+//
+// Returns:
+//   - The number of keys removed.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session:1", "member1")
+//	set.SAdd("session:2", "member2")
+//	set.SAdd("user:1", "member3")
+//	removed := set.SClearMatch("session:*")
+//
+// In this example, "session:1" and "session:2" are deleted, "user:1" is kept, and 'removed' will be 2.
+func (s *Set) SClearMatch(pattern string) int {
+	s.lock()
+	defer s.unlock()
+
+	var matched []string
+	for key := range s.records {
+		if !s.expired(key) && globMatch(pattern, key) {
+			matched = append(matched, key)
+		}
+	}
+
+	for _, key := range matched {
+		s.drop(key)
+	}
+
+	return len(matched)
+}
+
+// SKeys returns all the keys currently present in the Set. The order of the keys is not specified.
+//
+// Returns:
+//   - A slice containing every key in the Set, or an empty slice if the Set holds no keys.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2")
+//	keys := set.SKeys()
+//
+// In this example, 'keys' will contain "set1" and "set2."
+func (s *Set) SKeys() []string {
+	return s.SKeysMatch("*")
+}
+
+// SKeysMatch returns the keys currently present in the Set that match the given Redis-style glob pattern,
+// using the same syntax as SScanMatch. The order of the keys is not specified.
+//
+// Parameters:
+//   - pattern: 	The glob pattern the keys must match.
+//
+// Returns:
+//   - A slice containing every matching key, or an empty slice if none match.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("user:1", "member1")
+//	set.SAdd("user:2", "member2")
+//	set.SAdd("order:1", "member3")
+//	keys := set.SKeysMatch("user:*")
+//
+// In this example, 'keys' will contain "user:1" and "user:2."
+func (s *Set) SKeysMatch(pattern string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.records))
+	for key := range s.records {
+		if !s.expired(key) && globMatch(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// SFlush deletes every key and its associated set from the records, leaving the Set empty.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2")
+//	set.SFlush()
+//
+// In this example, both "set1" and "set2" are deleted from the records.
+func (s *Set) SFlush() {
+	s.SFlushCount()
+}
+
+// SFlushCount deletes every key and its associated set from the records, like SFlush,
+// and reports how many keys were removed.
+//
+// Returns:
+//   - The number of keys removed.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2")
+//	removed := set.SFlushCount()
+//
+// In this example, both sets are deleted and 'removed' will be 2.
+func (s *Set) SFlushCount() int {
+	s.lock()
+	defer s.unlock()
+
+	removed := 0
+	for key := range s.records {
+		if !s.expired(key) {
+			removed++
+		}
+	}
+	s.reset(make(map[string]set))
+
+	return removed
+}
+
+// SDiff returns a new set that contains items which are in the first set but not in the others.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be used in the difference operation.
+//
+// Returns:
+//   - A slice containing the elements that are present in the first set but not in the other specified sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	result := set.SDiff("set1", "set2")
+//
+// In this example, the difference between "set1" and "set2" is computed, and 'result' contains elements unique to "set1."
+func (s *Set) SDiff(keys ...string) []interface{} {
+	if s.stats != nil {
+		s.stats.sDiff.Add(1)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if sets, ok := s.intKeys(keys); ok {
+		return diffInts(keys, sets)
+	}
+
+	return s.diffMembers(keys...)
+}
+
+// diffMembers returns the difference between the first set and the others as a slice.
+// The caller must hold the read lock.
+func (s *Set) diffMembers(keys ...string) []interface{} {
+	if len(keys) == 0 {
+		return []interface{}{}
+	}
+
+	firstSet, _ := s.view(keys[0])
+	if len(keys) == 1 {
+		return firstSet.list()
+	}
+
+	excludeMap := make(map[interface{}]bool)
+
+	for _, key := range keys {
+		if key != keys[0] {
+			nextSet, ok := s.view(key)
+			if !ok {
+				return []interface{}{}
+			}
+
+			for item := range nextSet {
+				excludeMap[item] = true
+			}
+		}
+
+	}
+
+	result := make([]interface{}, 0, len(firstSet))
+
+	for item := range firstSet {
+		if !excludeMap[item] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// SDiffStore computes the set difference between the first key provided and all the other keys.
+// It stores the result in a new set identified by storeKey. If the destination
+// set (storeKey) already exists, it will be overridden with the new difference results. If the result is empty,
+// storeKey is deleted. The result is computed in full before storeKey is written, so storeKey may also be
+// one of the source keys.
+//
+// Parameters:
+//   - storeKey: 	The key where the resulting set difference will be stored.
+//   - keys: 		One or more keys associated with the sets to calculate the difference.
+//
+// Returns:
+//   - The number of elements in the resulting difference set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	count := set.SDiffStore("resultSet", "set1", "set2")
+//
+// In this example, it calculates the difference between "set1" and "set2" and stores the result in "resultSet."
+// The resulting difference set contains "member1," and 'count' will be 1.
+func (s *Set) SDiffStore(storeKey string, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	return s.store(storeKey, setOf(s.diffMembers(keys...)...))
+}
+
+// SDiffBaselineStore computes, for each of the given keys, the difference between its set and the baseline set,
+// and stores it under prefix+key, as SDiffStore(prefix+key, key, baseline) would. Every difference is computed
+// before any result is stored, and all of them are stored atomically, so a derived key may also be a source key.
+// As with SDiff, a non-existent key yields an empty difference, and so does every key if the baseline does not
+// exist. An empty difference deletes its derived key.
+//
+// Parameters:
+//   - baseline: 	The key associated with the set subtracted from every other set.
+//   - keys: 		The keys associated with the sets to subtract the baseline from.
+//   - prefix: 		The prefix prepended to each key to name the key its difference is stored under.
+//
+// Returns:
+//   - A map from each key to the number of members in its stored difference.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("baseline", "member1")
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member1", "member3", "member4")
+//	sizes := set.SDiffBaselineStore("baseline", []string{"set1", "set2"}, "new:")
+//
+// In this example, "new:set1" holds "member2" and "new:set2" holds "member3" and "member4,"
+// and 'sizes' will be map[set1:1 set2:2].
+func (s *Set) SDiffBaselineStore(baseline string, keys []string, prefix string) map[string]int {
+	s.lock()
+	defer s.unlock()
+
+	diffs := make(map[string]set, len(keys))
+	for _, key := range keys {
+		diffs[key] = setOf(s.diffMembers(key, baseline)...)
+	}
+
+	sizes := make(map[string]int, len(diffs))
+	for key, diff := range diffs {
+		sizes[key] = s.store(prefix+key, diff)
+	}
+
+	return sizes
+}
+
+// SInter returns a new set that contains items present in all the specified sets.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be intersected.
+//
+// Returns:
+//   - A slice containing the intersection of elements from all the specified sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	result := set.SInter("set1", "set2")
+//
+// In this example, the intersection of "set1" and "set2" is computed, and 'result'.
+func (s *Set) SInter(keys ...string) []interface{} {
+	if s.stats != nil {
+		s.stats.sInter.Add(1)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if sets, ok := s.intKeys(keys); ok {
+		return interInts(sets)
+	}
+
+	return s.interMembers(keys...)
+}
+
+// interMembers returns the intersection of the sets associated with the keys as a slice.
+// The caller must hold the read lock.
+func (s *Set) interMembers(keys ...string) []interface{} {
+	if len(keys) == 0 {
+		return []interface{}{}
+	}
+
+	// Every set is looked up before the smallest one is selected, so that a missing key, wherever it
+	// appears, makes the intersection empty without any member being scanned.
+	sets := make([]set, len(keys))
+	for i, key := range keys {
+		currentSet, ok := s.view(key)
+		if !ok {
+			return []interface{}{}
+		}
+		sets[i] = currentSet
+	}
+
+	smallest := 0
+	for i, currentSet := range sets {
+		if currentSet.size() < sets[smallest].size() {
+			smallest = i
+		}
+	}
+
+	if sets[smallest].size() == 0 {
+		return []interface{}{}
+	}
+
+	others := make([]set, 0, len(sets)-1)
+	for i, currentSet := range sets {
+		if i != smallest {
+			others = append(others, currentSet)
+		}
+	}
+
+	result := make([]interface{}, 0, sets[smallest].size())
+	for item := range sets[smallest] {
+		if inAllSets(item, others) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// SInterStore computes the intersection of sets specified by the provided keys
+// and stores the result in a new set identified by storeKey. If the destination
+// set (storeKey) already exists, it will be overridden with the new intersection results. If the result is empty,
+// storeKey is deleted. The result is computed in full before storeKey is written, so storeKey may also be
+// one of the source keys.
+//
+// Parameters:
+//   - storeKey: 	The key where the resulting intersection will be stored.
+//   - keys: 		One or more keys associated with the sets to be intersected.
+//
+// Returns:
+//   - The number of elements in the resulting intersection set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	count := set.SInterStore("resultSet", "set1", "set2")
+//
+// In this example, it calculates the intersection of "set1" and "set2" and stores the result in "resultSet."
+// The resulting intersection set contains "member2" and "member3," and 'count' will be 2.
+func (s *Set) SInterStore(storeKey string, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	return s.store(storeKey, setOf(s.interMembers(keys...)...))
+}
+
+// SUnionCard returns the number of members in the union of the specified sets, like len(SUnion(keys...)),
+// without building the result slice. Non-existent keys contribute no members.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be combined.
+//
+// Returns:
+//   - The number of members in the union.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	count := set.SUnionCard("set1", "set2")
+//
+// In this example, the union of "set1" and "set2" has three members, and 'count' will be 3.
+func (s *Set) SUnionCard(keys ...string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(keys) == 1 {
+		return s.get(keys[0]).size()
+	}
+
+	seen := newSet()
+	for _, key := range keys {
+		for item := range s.get(key) {
+			seen[item] = keyExists
+		}
+	}
+
+	return seen.size()
+}
+
+// SUnionCounts returns every member of the union of the specified sets, mapped to the number of sets containing it.
+// A member mapped to 1 is in a single set, and a member mapped to the number of sets is in their intersection.
+// Non-existent keys contribute no members, and a key given several times is counted once.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be combined.
+//
+// Returns:
+//   - A map from every member of the union to the number of sets containing it.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	counts := set.SUnionCounts("set1", "set2")
+//
+// In this example, 'counts' will be map[member1:1 member2:2 member3:1].
+func (s *Set) SUnionCounts(keys ...string) map[interface{}]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[interface{}]int)
+	counted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if counted[key] {
+			continue
+		}
+		counted[key] = true
+
+		for item := range s.get(key) {
+			counts[item]++
+		}
+	}
+
+	return counts
+}
+
+// SDiffCard returns the number of members in the difference between the first set and the other sets,
+// like len(SDiff(keys...)), without building the result slice or the set of excluded members.
+// As with SDiff, the difference is empty if any of the keys does not exist.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets, the first one being the set to subtract from.
+//
+// Returns:
+//   - The number of members in the difference.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2")
+//	count := set.SDiffCard("set1", "set2")
+//
+// In this example, the difference has two members, "member1" and "member3," and 'count' will be 2.
+func (s *Set) SDiffCard(keys ...string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(keys) == 0 {
+		return 0
+	}
+
+	firstSet := s.get(keys[0])
+	excluded := make([]set, 0, len(keys)-1)
+
+	for _, key := range keys[1:] {
+		if key == keys[0] {
+			continue
+		}
+
+		nextSet, ok := s.lookup(key)
+		if !ok {
+			return 0
+		}
+
+		excluded = append(excluded, nextSet)
+	}
+
+	count := 0
+	for item := range firstSet {
+		if !inAnySet(item, excluded) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SInterCard returns the number of members in the intersection of the specified sets without building it,
+// following Redis SINTERCARD. Counting stops as soon as limit is reached, so a small limit answers threshold
+// questions cheaply. A limit less than or equal to 0 means no limit.
+// If any key does not exist, the intersection is empty and it returns 0.
+//
+// Parameters:
+//   - limit: 	The count at which to stop counting, or 0 for no limit.
+//   - keys: 	The keys associated with the sets to be intersected.
+//
+// Returns:
+//   - The number of members in the intersection, capped at limit.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	count := set.SInterCard(0, "set1", "set2")
+//
+// In this example, the intersection of "set1" and "set2" has two members, and 'count' will be 2.
+func (s *Set) SInterCard(limit int, keys ...string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(keys) == 0 {
+		return 0
+	}
+
+	sets := make([]set, len(keys))
+	smallest := 0
+
+	for i, key := range keys {
+		currentSet, ok := s.lookup(key)
+		if !ok {
+			return 0
+		}
+
+		sets[i] = currentSet
+		if len(currentSet) < len(sets[smallest]) {
+			smallest = i
+		}
+	}
+
+	count := 0
+	for item := range sets[smallest] {
+		if !inAllSets(item, sets) {
+			continue
+		}
+
+		count++
+		if limit > 0 && count == limit {
+			break
+		}
+	}
+
+	return count
+}
+
+// SSymDiff returns the symmetric difference of the specified sets, that is the members present in an odd
+// number of them. For two sets, this is the classic symmetric difference: members in exactly one of the sets.
+// Non-existent keys contribute nothing to the result.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to be used in the symmetric difference.
+//
+// Returns:
+//   - A slice containing the members present in an odd number of the specified sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	result := set.SSymDiff("set1", "set2")
+//
+// In this example, the symmetric difference of "set1" and "set2" is computed, and 'result' contains "member1" and "member4."
+func (s *Set) SSymDiff(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.symDiff(keys...).list()
+}
+
+// SSymDiffStore computes the symmetric difference of the specified sets and stores the result in a new set
+// identified by destKey. If the destination set (destKey) already exists, it will be overridden with the new
+// symmetric difference results. If the result is empty, destKey is deleted.
+//
+// Parameters:
+//   - destKey: 	The key where the resulting symmetric difference will be stored.
+//   - keys: 		One or more keys associated with the sets to be used in the symmetric difference.
+//
+// Returns:
+//   - The number of elements in the resulting symmetric difference set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	count := set.SSymDiffStore("resultSet", "set1", "set2")
+//
+// In this example, the symmetric difference of "set1" and "set2" is stored in "resultSet," and 'count' will be 2.
+func (s *Set) SSymDiffStore(destKey string, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	return s.store(destKey, s.symDiff(keys...))
+}
+
+// SDuplicate duplicates the set associated with srcKey into destKey. If the destination
+// set (destKey) already exists, it will be overridden. If the source set does not exist, it returns false.
+//
+// With deep set to true, the destination receives an independent copy of the source members.
+// With deep set to false, the destination shares the same underlying set as the source, so any
+// mutation made through one key is visible through the other. Shallow duplication avoids copying
+// and is useful for read-mostly derived keys, but it is dangerous if either key is mutated later:
+//   - A write through one key changes the other without its bookkeeping: observers only hear of the key written
+//     to, and the insertion order, watched intersections and cap of the other key are not updated or enforced.
+//   - Emptying one key deletes it, but leaves the other as an existing key holding an empty set.
+//
+// Parameters:
+//   - srcKey: 	The key associated with the set to be duplicated.
+//   - destKey: 	The key where the duplicate will be stored.
+//   - deep: 		Whether the destination gets an independent copy (true) or aliases the source (false).
+//
+// Returns:
+//   - true if the set was duplicated, false if the source set does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	set.SDuplicate("myset", "backup", true)
+//
+// In this example, "backup" receives an independent copy of "myset," and the function returns true.
+func (s *Set) SDuplicate(srcKey, destKey string, deep bool) bool {
+	s.lock()
+	defer s.unlock()
+
+	if !s.exists(srcKey) {
+		return false
+	}
+
+	srcSet := s.get(srcKey)
+	if deep {
+		s.put(destKey, srcSet.copy())
+	} else {
+		s.put(destKey, srcSet)
+	}
+
+	return true
+}
+
+// SMembersWithPrefix returns the members of the set associated with the given key that are strings
+// starting with the specified prefix. Members that are not strings are ignored.
+// If the key does not exist, it returns an empty slice.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - prefix: 	The prefix the returned members must start with. An empty prefix matches every string member.
+//
+// Returns:
+//   - A slice containing the string members starting with prefix.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("terms", "apple", "apricot", "banana", 42)
+//	matches := set.SMembersWithPrefix("terms", "ap")
+//
+// In this example, 'matches' will contain "apple" and "apricot."
+func (s *Set) SMembersWithPrefix(key, prefix string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.exists(key) {
+		return []interface{}{}
+	}
+
+	result := make([]interface{}, 0)
+	for item := range s.get(key) {
+		if str, ok := item.(string); ok && strings.HasPrefix(str, prefix) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// SCASMember replaces oldMember with newMember in the set associated with the given key, but only if
+// oldMember is currently a member of the set. This makes it possible to model a state machine as a
+// single-member set and only transition it from the expected state. If either member cannot be used as a map key,
+// such as a slice or a map, nothing is replaced and false is returned.
+//
+// Parameters:
+//   - key: 		The key associated with the set.
+//   - oldMember: 	The member expected to be in the set.
+//   - newMember: 	The member replacing oldMember.
+//
+// Returns:
+//   - true if oldMember was present and has been replaced, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("state", "pending")
+//	swapped := set.SCASMember("state", "pending", "running")
+//
+// In this example, "pending" is replaced with "running" in the set "state," and 'swapped' will be true.
+func (s *Set) SCASMember(key string, oldMember, newMember interface{}) bool {
+	swapped := false
+	mutations := s.mutate(func() {
+		if !isComparable(oldMember) || !isComparable(newMember) || !s.fieldExists(key, oldMember) {
+			return
+		}
+
+		swapped = true
+		if oldMember == newMember {
+			return
+		}
+
+		set := s.get(key)
+		s.evict(key, set, oldMember)
+		if !set.has(newMember) {
+			s.insert(key, set, newMember)
+		}
+	})
+
+	s.notify(mutations)
+	return swapped
+}
+
+// SHashJoin groups the members of the sets associated with keyA and keyB by the join key computed by keyFn.
+// For every join key, the returned map holds the matching members from keyA at index 0 and the matching
+// members from keyB at index 1. Join keys found in only one of the sets are kept, with an empty group on
+// the other side. Non-existent keys contribute no members.
+//
+// Parameters:
+//   - keyA: 	The key associated with the first set.
+//   - keyB: 	The key associated with the second set.
+//   - keyFn: 	The function computing the join key of a member. It must return a comparable value.
+//
+// Returns:
+//   - A map from each join key to the members of both sets sharing it.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("orders", order{ID: 1}, order{ID: 2})
+//	set.SAdd("shipments", shipment{OrderID: 1})
+//	joined := set.SHashJoin("orders", "shipments", func(item interface{}) interface{} {
+//		switch v := item.(type) {
+//		case order:
+//			return v.ID
+//		case shipment:
+//			return v.OrderID
+//		}
+//		return nil
+//	})
+//
+// In this example, 'joined[1]' holds the first order and its shipment, while 'joined[2]' only holds the second order.
+func (s *Set) SHashJoin(keyA, keyB string, keyFn func(item interface{}) interface{}) map[interface{}][2][]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	joined := make(map[interface{}][2][]interface{})
+
+	for side, key := range [2]string{keyA, keyB} {
+		currentSet, ok := s.lookup(key)
+		if !ok {
+			continue
+		}
+
+		for item := range currentSet {
+			joinKey := keyFn(item)
+			groups := joined[joinKey]
+			groups[side] = append(groups[side], item)
+			joined[joinKey] = groups
+		}
+	}
+
+	return joined
+}
+
+// AllCardinalities returns every key in the Set mapped to the number of members of its set,
+// computed in a single pass so that the result is a consistent snapshot of all the sizes.
+// Keys holding an empty set are included with a value of 0.
+//
+// Returns:
+//   - A map from every key to the size of its set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member3")
+//	sizes := set.AllCardinalities()
+//
+// In this example, 'sizes' will be map[set1:2 set2:1].
+func (s *Set) AllCardinalities() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cardinalities := make(map[string]int, len(s.records))
+	for key, set := range s.records {
+		if s.expired(key) {
+			continue
+		}
+		cardinalities[key] = set.size()
+	}
+
+	return cardinalities
+}
+
+// SReverseDiffStore computes the members present in any of the sets associated with otherKeys but absent from
+// the set associated with baseKey, and stores the result in a new set identified by destKey. This is the
+// complement of SDiffStore: it captures what the others have that the base lacks. If the destination set
+// (destKey) already exists, it will be overridden. If the result is empty, destKey is deleted.
+// Non-existent keys are treated as empty sets.
+//
+// Parameters:
+//   - destKey: 	The key where the resulting set will be stored.
+//   - baseKey: 	The key associated with the base set.
+//   - otherKeys: 	The keys associated with the sets compared against the base set.
+//
+// Returns:
+//   - The number of elements in the resulting set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("expected", "member1", "member2")
+//	set.SAdd("seen1", "member1", "member3")
+//	set.SAdd("seen2", "member4")
+//	count := set.SReverseDiffStore("unexpected", "expected", "seen1", "seen2")
+//
+// In this example, "unexpected" will contain "member3" and "member4," and 'count' will be 2.
+func (s *Set) SReverseDiffStore(destKey, baseKey string, otherKeys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	others := make([]set, 0, len(otherKeys))
+	for _, key := range otherKeys {
+		if otherSet, ok := s.lookup(key); ok {
+			others = append(others, otherSet)
+		}
+	}
+
+	result := union(others...)
+	if baseSet, ok := s.lookup(baseKey); ok {
+		result = difference(result, baseSet)
+	}
+
+	return s.store(destKey, result)
+}
+
+// SContainsMap checks the membership of every candidate against the set associated with the given key and
+// returns each candidate mapped to whether it is a member. Unlike a positional result, duplicate candidates
+// collapse into a single entry. If the key does not exist, every candidate is mapped to false.
+//
+// Parameters:
+//   - key: 		The key associated with the set.
+//   - candidates: 	The members to check for existence in the set.
+//
+// Returns:
+//   - A map from each candidate to true if it exists in the set, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	found := set.SContainsMap("myset", []interface{}{"member1", "member3"})
+//
+// In this example, 'found' will be map[member1:true member3:false].
+func (s *Set) SContainsMap(key string, candidates []interface{}) map[interface{}]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found := make(map[interface{}]bool, len(candidates))
+	set := s.get(key)
+
+	for _, candidate := range candidates {
+		_, exists := set[candidate]
+		found[candidate] = exists
+	}
+
+	return found
+}
+
+// SRandomPair returns two distinct random members of the set associated with the given key, drawn using r.
+// A given seed of r always draws the same pair from the same members. The set is not modified. If the key does not exist or the set has fewer than two members, ok is false.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - r: 		The source of randomness used to pick the members, or nil to use the source of the Set.
+//
+// Returns:
+//   - Two distinct random members of the set and true, or nil, nil and false if the set has fewer than two members.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("players", "alice", "bob", "carol")
+//	a, b, ok := set.SRandomPair("players", rand.New(rand.NewSource(1)))
+//
+// In this example, 'a' and 'b' are two different players to be compared, and 'ok' will be true.
+func (s *Set) SRandomPair(key string, r *rand.Rand) (a, b interface{}, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	if set.size() < 2 {
+		return nil, nil, false
+	}
+
+	// The members are ordered, so that a given r always draws the same pair regardless of the map iteration order.
+	// Without r, the members are drawn like those of SRandMember, from the source of the Set.
+	var members []interface{}
+	if r == nil {
+		s.rngMu.Lock()
+		defer s.rngMu.Unlock()
+
+		members, r = s.candidates(set), s.rng
+	} else {
+		members = set.sortedList()
+	}
+
+	i := r.Intn(len(members))
+	j := r.Intn(len(members) - 1)
+	if j >= i {
+		j++
+	}
+
+	return members[i], members[j], true
+}
+
+// SScan incrementally iterates over the members of the set associated with the given key, returning up to count
+// members per call, following Redis SSCAN. Start with a cursor of 0 and pass the returned cursor to the next call;
+// a returned cursor of 0 means the iteration is complete. If count is less than 1, a default of 10 is used.
+//
+// The cursor is a position in the order of the members' formatted values, which does not depend on the map
+// iteration order. As long as the set is not modified during the iteration, every member is returned exactly once.
+// If members are added or removed between calls, members may be missed or returned more than once; use
+// SScanStable when the set is mutated during the iteration.
+//
+// Unlike in Redis, count is a hard bound rather than a hint: each call advances the cursor by exactly count members,
+// so a full iteration takes ceil(SCard(key) / count) calls, and at most count members are returned or, for
+// SScanMatch, matched against the pattern. The order is computed by the first call and reused by the following ones
+// until the Set is next modified, so a full iteration over an unmodified set only sorts it once.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - cursor: 	The cursor returned by the previous call, or 0 to start the iteration.
+//   - count: 	The number of members to advance by, and the maximum number of members to return.
+//
+// Returns:
+//   - The cursor to pass to the next call, or 0 if the iteration is complete.
+//   - A slice containing the members of the page.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	cursor, page := set.SScan("myset", 0, 2)
+//	cursor, page = set.SScan("myset", cursor, 2)
+//
+// In this example, the first call returns two members, and the second one returns the last member with a cursor of 0.
+func (s *Set) SScan(key string, cursor int, count int) (nextCursor int, members []interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.scan(key, cursor, count, "*")
+}
+
+// SScanMatch works like SScan, but only returns the members whose string form matches the Redis-style glob
+// pattern. Supported wildcards are * (any sequence of characters), ? (a single character) and [...] (a character
+// class, with ranges such as [a-z] and negation such as [^a]), and \ escapes the character that follows it.
+// Non-string members are matched against their fmt.Sprint representation.
+//
+// As in Redis, the filter is applied to each page after it has been taken, so a page may contain fewer than
+// count members, or none at all, while the cursor still advances. Keep calling until the cursor is 0. As for SScan,
+// count bounds the members examined by each call, so a heavily filtered scan does not match the whole set at once.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - cursor: 	The cursor returned by the previous call, or 0 to start the iteration.
+//   - count: 	The maximum number of members to examine.
+//   - pattern: The glob pattern members must match.
+//
+// Returns:
+//   - The cursor to pass to the next call, or 0 if the iteration is complete.
+//   - A slice containing the matching members of the page.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "user:1", "user:2", "session:1")
+//	cursor, page := set.SScanMatch("myset", 0, 10, "user:*")
+//
+// In this example, 'page' contains "user:1" and "user:2," and 'cursor' will be 0.
+func (s *Set) SScanMatch(key string, cursor int, count int, pattern string) (nextCursor int, members []interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.scan(key, cursor, count, pattern)
+}
+
+// scan returns the page of members of the set associated with the key starting at cursor, keeping only those
+// whose string form matches pattern. The caller must hold the read lock.
+func (s *Set) scan(key string, cursor int, count int, pattern string) (nextCursor int, members []interface{}) {
+	if count < 1 {
+		count = 10
+	}
+
+	ordered := s.scanOrder(key)
+	if cursor < 0 || cursor >= len(ordered) {
+		return 0, []interface{}{}
+	}
+
+	if count >= len(ordered)-cursor {
+		nextCursor = 0
+		ordered = ordered[cursor:]
+	} else {
+		nextCursor = cursor + count
+		ordered = ordered[cursor:nextCursor]
+	}
+
+	if pattern == "*" {
+		return nextCursor, append([]interface{}{}, ordered...)
+	}
+
+	members = make([]interface{}, 0, len(ordered))
+	for _, item := range ordered {
+		if globMatch(pattern, memberString(item)) {
+			members = append(members, item)
+		}
+	}
+
+	return nextCursor, members
+}
+
+// SScanStable iterates over the members of the set associated with the given key in pages of up to count members.
+// Members are visited in the order of their formatted value, and the returned token encodes the position of the
+// last member of the page, so that iteration resumes at the right place even if the set changed between calls.
+// An empty token starts the iteration and an empty nextToken signals that it is complete.
+// If count is less than 1, a default of 10 is used. If the key does not exist, the iteration is complete at once.
+//
+// Guarantees, for an iteration running while the set is modified between pages:
+//   - A member present during the whole iteration is returned exactly once.
+//   - A member removed before its page is reached is not returned.
+//   - A member added during the iteration is returned only if it sorts after the current position.
+//
+// Members that share both their formatted value and their type are indistinguishable to the token,
+// so such members straddling a page boundary may be skipped.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - token: 	The token returned by the previous call, or an empty string to start the iteration.
+//   - count: 	The maximum number of members to return.
+//
+// Returns:
+//   - A slice containing the members of the page.
+//   - The token to pass to the next call, or an empty string if the iteration is complete.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	page, token := set.SScanStable("myset", "", 2)
+//	page, token = set.SScanStable("myset", token, 2)
+//
+// In this example, the first call returns "member1" and "member2," and the second one returns "member3" with an empty token.
+func (s *Set) SScanStable(key string, token string, count int) (members []interface{}, nextToken string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if count < 1 {
+		count = 10
+	}
+
+	// The sort key of every member is computed once, alongside the member, rather than on every comparison.
+	type keyedMember struct {
+		sortKey string
+		member  interface{}
+	}
+
+	remaining := make([]keyedMember, 0)
+	for item := range s.get(key) {
+		if sortKey := memberSortKey(item); token == "" || sortKey > token {
+			remaining = append(remaining, keyedMember{sortKey: sortKey, member: item})
+		}
+	}
+
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].sortKey < remaining[j].sortKey
+	})
+
+	if len(remaining) > count {
+		nextToken = remaining[count-1].sortKey
+		remaining = remaining[:count]
+	}
+
+	members = make([]interface{}, len(remaining))
+	for i, keyed := range remaining {
+		members[i] = keyed.member
+	}
+
+	return members, nextToken
+}
+
+// SAddReturningSet adds one or more members to the set associated with the provided key, exactly like SAdd,
+// and returns a new, independent Set holding, under the same key, only the members newly added by this call.
+// Members that were already in the set are not part of the returned Set, which makes it possible to
+// run further set operations on the delta right away.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: One or more members to be added to the set.
+//
+// Returns:
+//   - A new Set containing the newly added members under key, or no key at all if nothing was added.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1")
+//	delta := set.SAddReturningSet("myset", "member1", "member2")
+//
+// In this example, "member2" is added to "myset," and 'delta' holds only "member2" under the key "myset."
+func (s *Set) SAddReturningSet(key string, members ...interface{}) *Set {
+	added := newSet()
+	mutations := s.mutate(func() {
+		// The members missing from the set before the single addition pass, and in it afterwards, are those added.
+		before := s.get(key)
+		for _, member := range members {
+			if isComparable(member) && !before.has(member) {
+				added.add(member)
+			}
+		}
+
+		s.addMembers(key, members...)
+		after := s.get(key)
+		for member := range added {
+			if !after.has(member) {
+				added.remove(member)
+			}
+		}
+	})
+
+	s.notify(mutations)
+
+	delta := New()
+	if added.size() > 0 {
+		delta.records[key] = added
+	}
+
+	return delta
+}
+
+// SOverlapStore stores, into destKey, the members appearing in at least minCount of the sets associated with
+// the provided keys. This generalizes intersection (minCount equal to the number of keys) and union (minCount of 1)
+// into a tunable threshold. A minCount below 1 is treated as 1. If the destination set (destKey) already exists,
+// it will be overridden. If the result is empty, destKey is deleted. Non-existent keys contribute no members.
+//
+// Parameters:
+//   - destKey: 	The key where the resulting set will be stored.
+//   - minCount: 	The minimum number of sets a member must appear in.
+//   - keys: 		The keys associated with the sets to be counted.
+//
+// Returns:
+//   - The number of elements in the resulting set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	set.SAdd("set3", "member3", "member4")
+//	count := set.SOverlapStore("resultSet", 2, "set1", "set2", "set3")
+//
+// In this example, "resultSet" will contain "member2" and "member3," and 'count' will be 2.
+func (s *Set) SOverlapStore(destKey string, minCount int, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	result := s.overlap(minCount, keys...)
+
+	return s.store(destKey, result)
+}
+
+// SInterThreshold returns the members present in at least k of the sets associated with the provided keys,
+// which lies between union (k of 1) and intersection (k equal to the number of keys). k is clamped to the range
+// from 1 to the number of keys. Non-existent keys contribute no members.
+//
+// Parameters:
+//   - k: 		The minimum number of sets a member must appear in.
+//   - keys: 	The keys associated with the sets to be counted.
+//
+// Returns:
+//   - A slice containing the members present in at least k of the sets.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	set.SAdd("set3", "member2", "member3", "member4")
+//	members := set.SInterThreshold(2, "set1", "set2", "set3")
+//
+// In this example, 'members' will contain "member2" and "member3."
+func (s *Set) SInterThreshold(k int, keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.overlap(clampThreshold(k, len(keys)), keys...).list()
+}
+
+// SInterThresholdStore stores, into storeKey, the members present in at least k of the sets associated with
+// the provided keys, as computed by SInterThreshold. If the destination set (storeKey) already exists, it will be
+// overridden. If the result is empty, storeKey is deleted.
+//
+// Parameters:
+//   - storeKey: 	The key where the resulting set will be stored.
+//   - k: 			The minimum number of sets a member must appear in.
+//   - keys: 		The keys associated with the sets to be counted.
+//
+// Returns:
+//   - The number of elements in the resulting set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	count := set.SInterThresholdStore("resultSet", 5, "set1", "set2")
+//
+// In this example, k is clamped to 2, "resultSet" will contain "member2," and 'count' will be 1.
+func (s *Set) SInterThresholdStore(storeKey string, k int, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	return s.store(storeKey, s.overlap(clampThreshold(k, len(keys)), keys...))
+}
+
+// clampThreshold clamps k to the range from 1 to n, the number of sets counted.
+func clampThreshold(k, n int) int {
+	return max(1, min(k, n))
+}
+
+// SClone returns a new Set holding an independent copy of every key and its associated set, so that mutating
+// the clone never affects the original Set and vice versa. Members themselves are not copied. The clone gets its
+// own source of randomness, seeded as by New. The expirations and maximum cardinalities of the keys are copied too.
+//
+// Returns:
+//   - A new Set with the same keys and members as the original Set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	snapshot := set.SClone()
+//	set.SAdd("myset", "member3")
+//
+// In this example, "myset" in 'snapshot' still holds only "member1" and "member2."
+func (s *Set) SClone() *Set {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clone := New(WithClock(s.now))
+	for key, set := range s.records {
+		if s.expired(key) {
+			continue
+		}
+
+		clone.records[key] = set.copy()
+		if at, ok := s.expires[key]; ok {
+			clone.setExpiry(key, at)
+		}
+	}
+
+	for key, maxCard := range s.maxCards {
+		clone.maxCards[key] = maxCard
+	}
+
+	for storeKey, keys := range s.interWatches {
+		clone.interWatches[storeKey] = append([]string(nil), keys...)
+	}
+
+	if s.order != nil {
+		clone.order = make(map[string]map[interface{}]uint64, len(s.order))
+		for key, positions := range s.order {
+			if _, ok := clone.records[key]; !ok {
+				continue
+			}
+
+			clone.order[key] = make(map[interface{}]uint64, len(positions))
+			for member, pos := range positions {
+				clone.order[key][member] = pos
+			}
+		}
+		clone.orderSeq = s.orderSeq
+	}
+
+	return clone
+}
+
+// SMergeFrom unions every set of other into the set associated with the same key in the receiver,
+// creating the keys that do not exist yet. The other Set is left unchanged.
+//
+// The sets of other are copied under its read lock before the receiver is locked, so two Sets may merge
+// from each other concurrently without deadlocking.
+//
+// Parameters:
+//   - other: 	The Set whose sets are merged into the receiver.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	other := New()
+//	other.SAdd("set1", "member2")
+//	other.SAdd("set2", "member3")
+//	set.SMergeFrom(other)
+//
+// In this example, "set1" will hold "member1" and "member2," and "set2" will hold "member3."
+func (s *Set) SMergeFrom(other *Set) {
+	records := other.snapshot()
+
+	mutations := s.mutate(func() {
+		for key, set := range records {
+			s.merge(key, set)
+		}
+	})
+
+	s.notify(mutations)
+}
+
+// merge adds the members of from to the set associated with the key, creating it if needed, and reports every
+// member added like insert does. The caller must hold the write lock.
+func (s *Set) merge(key string, from set) {
+	if !s.exists(key) {
+		s.put(key, newSet())
+	}
+	defer s.deleteIfEmpty(key)
+
+	into := s.get(key)
+	for member := range from {
+		if !into.has(member) {
+			s.insert(key, into, member)
+		}
+	}
+}
+
+// SMergeFromReplace copies every set of other into the receiver, like SMergeFrom, except that
+// a key present in both Sets is overwritten wholesale by the set of other instead of being merged.
+// Keys present only in the receiver are kept.
+//
+// Parameters:
+//   - other: 	The Set whose sets are copied into the receiver.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	other := New()
+//	other.SAdd("set1", "member2")
+//	set.SMergeFromReplace(other)
+//
+// In this example, "set1" will hold only "member2."
+func (s *Set) SMergeFromReplace(other *Set) {
+	records := other.snapshot()
+
+	s.lock()
+	defer s.unlock()
+
+	for key, set := range records {
+		s.put(key, set)
+	}
+}
+
+// SExport returns every key of the Set with the members of its set, as a plain map. The map and its slices are
+// copies, so mutating them never affects the Set, although members themselves are not copied. Empty and expired
+// keys are left out. The order of the members in each slice is not specified.
+//
+// Returns:
+//   - A map from every key to a slice holding the members of its set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	data := set.SExport()
+//
+// In this example, 'data' will be map[set1:[member1 member2]], in some member order.
+func (s *Set) SExport() map[string][]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := make(map[string][]interface{}, len(s.records))
+	for key, set := range s.records {
+		if set.size() > 0 && !s.expired(key) {
+			data[key] = set.list()
+		}
+	}
+
+	return data
+}
+
+// SImport loads a plain map of keys and their members, such as one returned by SExport. If replace is true,
+// the contents of the Set are replaced by data, and keys that are not in data are deleted. Otherwise the members
+// are added to the sets already associated with their keys, and other keys are kept. Keys with no members are
+// skipped, and so are members that cannot be members, such as slices or maps. The Set does not keep references
+// to data, so it may be mutated afterwards.
+//
+// Parameters:
+//   - data: 		The keys and their members to load.
+//   - replace: 	Whether data replaces the contents of the Set, rather than being merged into them.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SImport(map[string][]interface{}{"set1": {"member2"}, "set2": {"member3"}}, false)
+//
+// In this example, "set1" holds "member1" and "member2," and "set2" holds "member3."
+func (s *Set) SImport(data map[string][]interface{}, replace bool) {
+	records := make(map[string]set, len(data))
+	for key, members := range data {
+		imported := newSet()
+		for _, member := range members {
+			if isComparable(member) {
+				imported[member] = keyExists
+			}
+		}
+
+		if imported.size() > 0 {
+			records[key] = imported
+		}
+	}
+
+	mutations := s.mutate(func() {
+		if replace {
+			s.reset(records)
+			return
+		}
+
+		for key, set := range records {
+			s.merge(key, set)
+		}
+	})
+
+	s.notify(mutations)
+}
+
+// snapshot returns an independent copy of every non-empty set, keyed as in the records.
+func (s *Set) snapshot() map[string]set {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make(map[string]set, len(s.records))
+	for key, set := range s.records {
+		if set.size() > 0 && !s.expired(key) {
+			records[key] = set.copy()
+		}
+	}
+
+	return records
+}
+
+// SIsSubset checks if every member of the set associated with subKey is also in the set associated with superKey.
+// A non-existent key is treated as an empty set, and the empty set is a subset of any set.
+//
+// Parameters:
+//   - subKey: 	The key associated with the candidate subset.
+//   - superKey: 	The key associated with the candidate superset.
+//
+// Returns:
+//   - true if the set associated with subKey is a subset of the set associated with superKey, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("granted", "read")
+//	set.SAdd("allowed", "read", "write")
+//	ok := set.SIsSubset("granted", "allowed")
+//
+// In this example, every member of "granted" is in "allowed," and 'ok' will be true.
+func (s *Set) SIsSubset(subKey, superKey string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.isSubset(subKey, superKey)
+}
+
+// SIsSuperset checks if the set associated with a contains every member of the set associated with b.
+// It is the inverse of SIsSubset, with the same handling of non-existent keys.
+//
+// Parameters:
+//   - a: 		The key associated with the candidate superset.
+//   - b: 		The key associated with the candidate subset.
+//
+// Returns:
+//   - true if the set associated with a is a superset of the set associated with b, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("allowed", "read", "write")
+//	set.SAdd("granted", "read")
+//	ok := set.SIsSuperset("allowed", "granted")
+//
+// In this example, "allowed" contains every member of "granted," and 'ok' will be true.
+func (s *Set) SIsSuperset(a, b string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.isSubset(b, a)
+}
+
+// SEquals checks if the sets associated with keyA and keyB hold exactly the same members, regardless of order.
+// A non-existent key is treated as an empty set, so two non-existent keys are equal. The sizes of the sets are
+// compared first, so sets of different sizes are told apart without looking at their members.
+//
+// Parameters:
+//   - keyA: 	The key associated with the first set.
+//   - keyB: 	The key associated with the second set.
+//
+// Returns:
+//   - true if both sets hold the same members, false otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member1")
+//	equal := set.SEquals("set1", "set2")
+//
+// In this example, both sets hold the same members, and 'equal' will be true.
+func (s *Set) SEquals(keyA, keyB string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.get(keyA).size() == s.get(keyB).size() && s.isSubset(keyA, keyB)
+}
+
+// SJaccard computes the Jaccard index of the sets associated with keyA and keyB, that is the size of their
+// intersection divided by the size of their union. The sizes are counted by iterating over the smaller set,
+// without building the intersection or the union. A non-existent key is treated as an empty set.
+//
+// Parameters:
+//   - keyA: 	The key associated with the first set.
+//   - keyB: 	The key associated with the second set.
+//
+// Returns:
+//   - A value in [0, 1]: 1 if both sets are empty, 0 if only one of them is, and |A ∩ B| / |A ∪ B| otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	similarity := set.SJaccard("set1", "set2")
+//
+// In this example, the sets share 2 of their 4 distinct members, and 'similarity' will be 0.5.
+func (s *Set) SJaccard(keyA, keyB string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setA, setB := s.get(keyA), s.get(keyB)
+	if setA.size() == 0 && setB.size() == 0 {
+		return 1
+	}
+
+	intersection := interSize(setA, setB)
+	return float64(intersection) / float64(setA.size()+setB.size()-intersection)
+}
+
+// SOverlap computes the overlap coefficient of the sets associated with keyA and keyB, that is the size of their
+// intersection divided by the size of the smaller set. It is 1 whenever one set is a subset of the other.
+// The intersection is counted as for SJaccard, and a non-existent key is treated as an empty set.
+//
+// Parameters:
+//   - keyA: 	The key associated with the first set.
+//   - keyB: 	The key associated with the second set.
+//
+// Returns:
+//   - A value in [0, 1]: 1 if both sets are empty, 0 if only one of them is, and |A ∩ B| / min(|A|, |B|) otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3", "member4", "member5")
+//	coefficient := set.SOverlap("set1", "set2")
+//
+// In this example, the sets share 1 member and the smaller one holds 2, so 'coefficient' will be 0.5.
+func (s *Set) SOverlap(keyA, keyB string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setA, setB := s.get(keyA), s.get(keyB)
+	switch {
+	case setA.size() == 0 && setB.size() == 0:
+		return 1
+	case setA.size() == 0 || setB.size() == 0:
+		return 0
+	}
+
+	return float64(interSize(setA, setB)) / float64(min(setA.size(), setB.size()))
+}
+
+// SDice computes the Sørensen–Dice coefficient of the sets associated with keyA and keyB, that is twice the size
+// of their intersection divided by the sum of their sizes. The intersection is counted as for SJaccard, and
+// a non-existent key is treated as an empty set.
+//
+// Parameters:
+//   - keyA: 	The key associated with the first set.
+//   - keyB: 	The key associated with the second set.
+//
+// Returns:
+//   - A value in [0, 1]: 1 if both sets are empty, 0 if only one of them is, and 2|A ∩ B| / (|A| + |B|) otherwise.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", "member2", "member3")
+//	set.SAdd("set2", "member2", "member3", "member4")
+//	coefficient := set.SDice("set1", "set2")
+//
+// In this example, the sets share 2 members out of 3 each, so 'coefficient' will be 4/6, about 0.667.
+func (s *Set) SDice(keyA, keyB string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setA, setB := s.get(keyA), s.get(keyB)
+	if setA.size() == 0 && setB.size() == 0 {
+		return 1
+	}
+
+	return 2 * float64(interSize(setA, setB)) / float64(setA.size()+setB.size())
+}
+
+// interSize returns the number of members in both a and b, iterating over the smaller set.
+func interSize(a, b set) int {
+	if a.size() > b.size() {
+		a, b = b, a
+	}
+
+	count := 0
+	for item := range a {
+		if _, ok := b[item]; ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SFingerprint computes a 64-bit fingerprint of the members of the set associated with the given key, for cheaply
+// detecting whether a set changed between two calls. The fingerprint is the XOR of the FNV-1a hashes of the
+// members, each hashed with its type, so it does not depend on the order in which members were added.
+// Different sets may collide, so equal fingerprints strongly suggest, but do not prove, equal sets.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - The fingerprint of the set, or 0 if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	before := set.SFingerprint("myset")
+//	set.SAdd("myset", "member3")
+//	changed := set.SFingerprint("myset") != before
+//
+// In this example, adding "member3" changes the fingerprint, and 'changed' will be true.
+func (s *Set) SFingerprint(key string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var fingerprint uint64
+	for item := range s.get(key) {
+		h := fnv.New64a()
+		h.Write([]byte(memberSortKey(item)))
+		fingerprint ^= h.Sum64()
+	}
+
+	return fingerprint
+}
+
+// isSubset checks if every member of the set associated with subKey is in the set associated with superKey.
+// The caller must hold the read lock.
+func (s *Set) isSubset(subKey, superKey string) bool {
+	subSet, superSet := s.get(subKey), s.get(superKey)
+	if subSet.size() > superSet.size() {
+		return false
+	}
+
+	for item := range subSet {
+		if _, ok := superSet[item]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SMembersSorted returns all the members of the set associated with the given key, sorted with the given
+// comparator. If the key does not exist, it returns an empty slice. The members are sorted once the lock has
+// been released, so less may call back into the Set.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - less: 	The comparator reporting whether a must be sorted before b.
+//
+// Returns:
+//   - A slice containing all the members of the set in the order defined by less.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 3, 1, 2)
+//	members := set.SMembersSorted("numbers", func(a, b interface{}) bool { return a.(int) < b.(int) })
+//
+// In this example, 'members' will be [1 2 3].
+func (s *Set) SMembersSorted(key string, less func(a, b interface{}) bool) []interface{} {
+	s.mu.RLock()
+	members := s.get(key).list()
+	s.mu.RUnlock()
+
+	sort.Slice(members, func(i, j int) bool {
+		return less(members[i], members[j])
+	})
+
+	return members
+}
+
+// SMembersSortedStrings returns the string form of every member of the set associated with the given key,
+// sorted lexicographically. Strings are returned as is, and other members in their fmt.Sprint form.
+// If the key does not exist, it returns an empty slice.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A sorted slice containing the string form of every member of the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "banana", "apple", 3)
+//	members := set.SMembersSortedStrings("myset")
+//
+// In this example, 'members' will be ["3", "apple", "banana"].
+func (s *Set) SMembersSortedStrings(key string) []string {
+	s.mu.RLock()
+	set := s.get(key)
+	members := make([]string, 0, len(set))
+	for item := range set {
+		members = append(members, memberString(item))
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(members)
+	return members
+}
+
+// SFilter returns the members of the set associated with the given key that satisfy pred.
+// pred is only called with the members of the set, so it is never called if the key does not exist.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - pred: 	The predicate members must satisfy to be returned.
+//
+// Returns:
+//   - A slice containing the members satisfying pred, or an empty slice if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 5, 10, 15)
+//	members := set.SFilter("numbers", func(item interface{}) bool { return item.(int) >= 5 })
+//
+// In this example, 'members' will contain 5, 10 and 15.
+func (s *Set) SFilter(key string, pred func(item interface{}) bool) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := []interface{}{}
+	for item := range s.get(key) {
+		if pred(item) {
+			members = append(members, item)
+		}
+	}
+
+	return members
+}
+
+// SCountFilter returns the number of members of the set associated with the given key that satisfy pred,
+// without collecting them. As with SFilter, pred is only called with the members of the set.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - pred: 	The predicate members must satisfy to be counted.
+//
+// Returns:
+//   - The number of members satisfying pred, or 0 if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 5, 10, 15)
+//	count := set.SCountFilter("numbers", func(item interface{}) bool { return item.(int) >= 5 })
+//
+// In this example, 'count' will be 3.
+func (s *Set) SCountFilter(key string, pred func(item interface{}) bool) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for item := range s.get(key) {
+		if pred(item) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SMax returns the greatest member of the set associated with the given key according to less, found in a single
+// pass over the set without sorting it. If several members are greatest, which of them is returned is not specified.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - less: 	The function reporting whether a orders before b.
+//
+// Returns:
+//   - The greatest member, and true, or nil and false if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 3, 10, 7)
+//	greatest, ok := set.SMax("numbers", func(a, b interface{}) bool { return a.(int) < b.(int) })
+//
+// In this example, 'greatest' will be 10 and 'ok' will be true.
+func (s *Set) SMax(key string, less func(a, b interface{}) bool) (interface{}, bool) {
+	return s.SMin(key, func(a, b interface{}) bool { return less(b, a) })
+}
+
+// SMin returns the least member of the set associated with the given key according to less, as described for SMax.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - less: 	The function reporting whether a orders before b.
+//
+// Returns:
+//   - The least member, and true, or nil and false if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 3, 10, 7)
+//	least, ok := set.SMin("numbers", func(a, b interface{}) bool { return a.(int) < b.(int) })
+//
+// In this example, 'least' will be 3 and 'ok' will be true.
+func (s *Set) SMin(key string, less func(a, b interface{}) bool) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var least interface{}
+	found := false
+
+	for item := range s.get(key) {
+		if !found || less(item, least) {
+			least, found = item, true
+		}
+	}
+
+	return least, found
+}
+
+// SForEach calls fn for each member of the set associated with the given key, in no particular order, without
+// copying the members into a slice. Returning true from fn continues the iteration, and returning false stops it,
+// as with the yield function of an iterator. If the key does not exist, fn is never called.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - fn: 		The function called with each member. Returning false stops the iteration.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	set.SForEach("myset", func(item interface{}) bool {
+//		fmt.Println(item)
+//		return true
+//	})
+//
+// In this example, every member of "myset" is printed.
+func (s *Set) SForEach(key string, fn func(item interface{}) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	s.get(key).foreach(fn)
+}
+
+// SReduce folds fn over the members of the set associated with the given key, starting from init, without copying
+// the members into a slice. Members are visited in no particular order, so fn should be commutative and associative
+// for the result not to depend on it. If the key does not exist, init is returned unchanged.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - init: 	The initial value of the accumulator.
+//   - fn: 		The function combining the accumulator with a member into the next accumulator.
+//
+// Returns:
+//   - The accumulator after every member has been folded in.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 2, 3)
+//	sum := set.SReduce("numbers", 0, func(acc, item interface{}) interface{} { return acc.(int) + item.(int) })
+//
+// In this example, 'sum' will be 6.
+func (s *Set) SReduce(key string, init interface{}, fn func(acc, item interface{}) interface{}) interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	acc := init
+	for item := range s.get(key) {
+		acc = fn(acc, item)
+	}
+
+	return acc
+}
+
+// SMapStore stores, into destKey, the result of fn applied to every member of the set associated with srcKey.
+// Members that fn maps to the same value collapse into one, so the result may hold fewer members than the source.
+// Results that cannot be members, such as slices or maps, are skipped. If the destination set (destKey) already
+// exists, it will be overridden, and if the result is empty, destKey is deleted. The result is computed in full
+// before destKey is written, so destKey may also be srcKey.
+//
+// Parameters:
+//   - srcKey: 	The key associated with the set to transform.
+//   - destKey: 	The key where the transformed set will be stored.
+//   - fn: 		The function transforming each member.
+//
+// Returns:
+//   - The number of elements in the resulting set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 2, 3, 4)
+//	count := set.SMapStore("numbers", "parities", func(item interface{}) interface{} { return item.(int) % 2 })
+//
+// In this example, "parities" will contain 0 and 1, and 'count' will be 2.
+func (s *Set) SMapStore(srcKey, destKey string, fn func(item interface{}) interface{}) int {
+	s.lock()
+	defer s.unlock()
+
+	result := newSet()
+	for item := range s.get(srcKey) {
+		if mapped := fn(item); isComparable(mapped) {
+			result[mapped] = keyExists
+		}
+	}
+
+	return s.store(destKey, result)
+}
+
+// SPartitionStore splits the set associated with srcKey in two: the members satisfying pred are stored into
+// matchKey and the others into restKey, overwriting both. An empty part deletes its key. Both parts are computed
+// before either key is written, so the source may also be one of the destinations; otherwise it is left unchanged.
+// If matchKey and restKey are the same key, it ends up holding the rest.
+//
+// Parameters:
+//   - srcKey: 	The key associated with the set to partition.
+//   - matchKey: 	The key where the members satisfying pred will be stored.
+//   - restKey: 	The key where the other members will be stored.
+//   - pred: 		The predicate deciding the part of each member.
+//
+// Returns:
+//   - matched: 	The number of members stored into matchKey.
+//   - rest: 		The number of members stored into restKey.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("numbers", 1, 2, 3, 4, 5)
+//	matched, rest := set.SPartitionStore("numbers", "even", "odd", func(item interface{}) bool { return item.(int)%2 == 0 })
+//
+// In this example, "even" will contain 2 and 4 and "odd" 1, 3 and 5, so 'matched' will be 2 and 'rest' will be 3.
+func (s *Set) SPartitionStore(srcKey, matchKey, restKey string, pred func(item interface{}) bool) (matched, rest int) {
+	s.lock()
+	defer s.unlock()
+
+	matchSet, restSet := newSet(), newSet()
+	for item := range s.get(srcKey) {
+		if pred(item) {
+			matchSet[item] = keyExists
+		} else {
+			restSet[item] = keyExists
+		}
+	}
+
+	matched = s.store(matchKey, matchSet)
+	rest = s.store(restKey, restSet)
+
+	return matched, rest
+}
+
+// SPopSpecific removes the given members from the set associated with the given key, and returns those that
+// were in the set, in the order they were given. Unlike SPop, the members are named rather than picked at
+// random, and unlike SRem, the removed members themselves are reported. If the set becomes empty, the key is deleted.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: The members to remove from the set.
+//
+// Returns:
+//   - A slice containing the members that were removed, or an empty slice if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	removed := set.SPopSpecific("myset", "member2", "member4")
+//
+// In this example, only "member2" was in the set, and 'removed' will be ["member2"].
+func (s *Set) SPopSpecific(key string, members ...interface{}) []interface{} {
+	removed := []interface{}{}
+	mutations := s.mutate(func() {
+		for _, member := range members {
+			if s.remMembers(key, member) == 1 {
+				removed = append(removed, member)
+			}
+		}
+	})
+
+	s.notify(mutations)
+	return removed
+}
+
+// SPeek returns up to count members of the set associated with the given key without removing them. Unlike
+// SRandMember, the members are simply the first ones met while iterating over the set: they come in no particular
+// order, but are not guaranteed to be random either, which makes SPeek cheaper than sampling.
+// If the key does not exist or the count is less than 1, it returns an empty slice.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - count: 	The maximum number of members to return.
+//
+// Returns:
+//   - A slice containing up to count members of the set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2", "member3")
+//	peeked := set.SPeek("myset", 2)
+//
+// In this example, 'peeked' holds two members of "myset," which still holds all three members.
+func (s *Set) SPeek(key string, count int) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if count < 1 {
+		return []interface{}{}
+	}
+
+	set := s.get(key)
+	members := make([]interface{}, 0, min(count, len(set)))
+	for item := range set {
+		if len(members) == count {
+			break
+		}
+		members = append(members, item)
+	}
+
+	return members
 }
 
-// SKeyExists checks if the specified key exists in the Set.
+// SUnionStoreMembers computes the union of multiple sets and stores it in storeKey, like SUnionStore,
+// but returns the stored members instead of their count, sparing a call to SMembers.
 //
 // Parameters:
-//   - key: 	The key to check for existence.
+//   - storeKey: 	The key where the union will be stored.
+//   - keys: 		The keys associated with the sets to be combined in the union.
 //
 // Returns:
-//   - true if the key exists in the Set, false otherwise.
+//   - A slice containing the members stored in storeKey, or an empty slice if the union is empty.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3")
-//	exists := set.SKeyExists("myset")
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	members := set.SUnionStoreMembers("unionSet", "set1", "set2")
 //
-// In this example, it checks if the key "myset" exists in the Set, and 'exists' will be true.
-func (s *Set) SKeyExists(key string) bool {
-	return s.exists(key)
+// In this example, "unionSet" and 'members' both hold "member1," "member2" and "member3."
+func (s *Set) SUnionStoreMembers(storeKey string, keys ...string) []interface{} {
+	s.lock()
+	defer s.unlock()
+
+	members := s.unionMembers(keys...)
+	s.store(storeKey, setOf(members...))
+
+	return members
 }
 
-// SClear deletes the specified key and its associated set from the records.
+// SDiffStoreMembers computes the difference between the first set and the others and stores it in storeKey,
+// like SDiffStore, but returns the stored members instead of their count, sparing a call to SMembers.
 //
 // Parameters:
-//   - key: 	The key associated with the set to be cleared.
+//   - storeKey: 	The key where the difference will be stored.
+//   - keys: 		The keys associated with the sets to be used in the difference operation.
+//
+// Returns:
+//   - A slice containing the members stored in storeKey, or an empty slice if the difference is empty.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("myset", "member1", "member2", "member3")
-//	set.SClear("myset")
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	members := set.SDiffStoreMembers("diffSet", "set1", "set2")
 //
-// In this example, the set associated with the key "myset" is deleted from the records.
-func (s *Set) SClear(key string) {
-	if s.exists(key) {
-		delete(s.records, key)
-	}
+// In this example, "diffSet" and 'members' both hold "member1."
+func (s *Set) SDiffStoreMembers(storeKey string, keys ...string) []interface{} {
+	s.lock()
+	defer s.unlock()
+
+	members := s.diffMembers(keys...)
+	s.store(storeKey, setOf(members...))
+
+	return members
 }
 
-// SDiff returns a new set that contains items which are in the first set but not in the others.
+// SInterStoreMembers computes the intersection of multiple sets and stores it in storeKey, like SInterStore,
+// but returns the stored members instead of their count, sparing a call to SMembers.
 //
 // Parameters:
-//   - keys: 	The keys associated with the sets to be used in the difference operation.
+//   - storeKey: 	The key where the intersection will be stored.
+//   - keys: 		The keys associated with the sets to be intersected.
 //
 // Returns:
-//   - A slice containing the elements that are present in the first set but not in the other specified sets.
+//   - A slice containing the members stored in storeKey, or an empty slice if the intersection is empty.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	result := set.SDiff("set1", "set2")
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	members := set.SInterStoreMembers("interSet", "set1", "set2")
 //
-// In this example, the difference between "set1" and "set2" is computed, and 'result' contains elements unique to "set1."
-func (s *Set) SDiff(keys ...string) []interface{} {
-	if len(keys) == 0 {
-		return []interface{}{}
-	}
-
-	if len(keys) == 1 {
-		if s.exists(keys[0]) {
-			return s.records[keys[0]].list()
-		}
-
-		return []interface{}{}
-	}
-
-	excludeMap := make(map[interface{}]bool)
-
-	for _, key := range keys {
-		if key != keys[0] {
-			nextSet, ok := s.records[key]
-			if !ok {
-				return []interface{}{}
-			}
-
-			for item := range nextSet {
-				excludeMap[item] = true
-			}
-		}
-
-	}
+// In this example, "interSet" and 'members' both hold "member2."
+func (s *Set) SInterStoreMembers(storeKey string, keys ...string) []interface{} {
+	s.lock()
+	defer s.unlock()
 
-	firstSet := s.records[keys[0]]
-	result := make([]interface{}, 0, len(firstSet))
-
-	for item := range firstSet {
-		if !excludeMap[item] {
-			result = append(result, item)
-		}
-	}
+	members := s.interMembers(keys...)
+	s.store(storeKey, setOf(members...))
 
-	return result
+	return members
 }
 
-// SDiffStore computes the set difference between the first key provided and all the other keys.
-// It stores the result in a new set identified by storeKey. If the destination
-// set (storeKey) already exists, it will be overridden with the new difference results.
+// MultiSAdd adds members to several sets at once, creating the keys that do not exist yet, like calling SAdd
+// for every entry of data. All the members are added under a single write lock, so concurrent readers either
+// see none or all of them.
 //
 // Parameters:
-//   - storeKey: 	The key where the resulting set difference will be stored.
-//   - keys: 		One or more keys associated with the sets to calculate the difference.
+//   - data: 	The members to add, keyed by the key associated with their set.
 //
 // Returns:
-//   - The number of elements in the resulting difference set.
+//   - A map from every key of data to the number of members added to its set.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	count := set.SDiffStore("resultSet", "set1", "set2")
-//
-// In this example, it calculates the difference between "set1" and "set2" and stores the result in "resultSet."
-// The resulting difference set contains "member1," and 'count' will be 1.
-func (s *Set) SDiffStore(storeKey string, keys ...string) int {
-	if s.exists(storeKey) {
-		delete(s.records, storeKey)
-	}
-
-	difference := s.SDiff(keys...)
-
-	for _, diffKey := range difference {
-		s.SAdd(storeKey, diffKey)
-	}
+//	added := set.MultiSAdd(map[string][]interface{}{
+//		"set1": {"member1", "member2"},
+//		"set2": {"member2"},
+//	})
+//
+// In this example, 'added' will be map[set1:2 set2:1].
+func (s *Set) MultiSAdd(data map[string][]interface{}) map[string]int {
+	added := make(map[string]int, len(data))
+	mutations := s.mutate(func() {
+		for key, members := range data {
+			added[key] = s.addMembers(key, members...)
+		}
+	})
 
-	return len(difference)
+	s.notify(mutations)
+	return added
 }
 
-// SInter returns a new set that contains items present in all the specified sets.
+// SCopy copies the members of the set associated with src into dest, following Redis COPY. The copy is
+// independent of the source, so later mutations of either set do not affect the other. The expiration of
+// src, if any, is copied as well. If dest already exists, nothing is copied unless replace is true, in
+// which case dest is overwritten.
 //
 // Parameters:
-//   - keys: 	The keys associated with the sets to be intersected.
+//   - src: 	The key associated with the set to be copied.
+//   - dest: 	The key where the copy will be stored.
+//   - replace: Whether an existing dest is overwritten.
 //
 // Returns:
-//   - A slice containing the intersection of elements from all the specified sets.
+//   - true if the set was copied, false if src does not exist or dest exists and replace is false.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	result := set.SInter("set1", "set2")
+//	set.SAdd("myset", "member1", "member2")
+//	copied := set.SCopy("myset", "backup", false)
 //
-// In this example, the intersection of "set1" and "set2" is computed, and 'result'.
-func (s *Set) SInter(keys ...string) []interface{} {
-	if len(keys) == 0 {
-		return []interface{}{}
-	}
+// In this example, "backup" receives a copy of "myset," and 'copied' will be true.
+func (s *Set) SCopy(src, dest string, replace bool) bool {
+	s.lock()
+	defer s.unlock()
 
-	if len(keys) == 1 {
-		if s.exists(keys[0]) {
-			return s.records[keys[0]].list()
-		}
-		return []interface{}{}
+	srcSet, ok := s.lookup(src)
+	if !ok || (!replace && s.exists(dest)) {
+		return false
 	}
 
-	var smallestSet set
-	var smallestKey string
-	var smallestSize = math.MaxInt
-
-	for _, key := range keys {
-		currentSet, ok := s.records[key]
-		if !ok {
-			return []interface{}{}
-		}
-
-		if len(currentSet) < smallestSize {
-			smallestSize = len(currentSet)
-			smallestSet = currentSet
-			smallestKey = key
-		}
+	s.put(dest, srcSet.copy())
+	if at, ok := s.expires[src]; ok {
+		s.setExpiry(dest, at)
 	}
 
-	inAllSets := make(map[interface{}]bool)
+	return true
+}
 
-	for item := range smallestSet {
-		inAllSets[item] = true
+// SKeyCount returns the number of keys in the Set.
+//
+// Returns:
+//   - The number of keys, or 0 if the Set is empty.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2", "member3")
+//	keys := set.SKeyCount()
+//
+// In this example, 'keys' will be 2.
+func (s *Set) SKeyCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for key := range s.records {
+		if !s.expired(key) {
+			count++
+		}
 	}
 
-	for _, key := range keys {
-		if key != smallestKey {
-			nextSet, ok := s.records[key]
-			if !ok {
-				return []interface{}{}
-			}
+	return count
+}
 
-			for item := range inAllSets {
-				if !nextSet.has(item) {
-					delete(inAllSets, item)
-				}
-			}
+// STotalCard returns the total number of members across every set of the Set, that is the sum of SCard over
+// every key. Members held by several sets are counted once per set.
+//
+// Returns:
+//   - The total number of members, or 0 if the Set is empty.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member1", "member2")
+//	total := set.STotalCard()
+//
+// In this example, 'total' will be 3.
+func (s *Set) STotalCard() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := 0
+	for key, set := range s.records {
+		if !s.expired(key) {
+			total += set.size()
 		}
 	}
 
-	result := make([]interface{}, 0, len(inAllSets))
-	for item := range inAllSets {
-		result = append(result, item)
-	}
-
-	return result
+	return total
 }
 
-// SInterStore computes the intersection of sets specified by the provided keys
-// and stores the result in a new set identified by storeKey. If the destination
-// set (storeKey) already exists, it will be overridden with the new intersection results.
+// SUnionParallel computes the same union as SUnion, but splits the keys into one chunk per available
+// processor (as reported by runtime.GOMAXPROCS) and computes the union of each chunk in its own goroutine,
+// before merging the partial unions. It pays off when combining many large sets; for a few small sets, the
+// cost of the goroutines outweighs the gain and SUnion is faster.
 //
 // Parameters:
-//   - storeKey: 	The key where the resulting intersection will be stored.
-//   - keys: 		One or more keys associated with the sets to be intersected.
+//   - keys: 	The keys associated with the sets to be combined in the union.
 //
 // Returns:
-//   - The number of elements in the resulting intersection set.
+//   - A slice containing the union of elements from all the specified sets.
 //
 // Example:
 //
 //	set := New()
-//	set.SAdd("set1", "member1", "member2", "member3")
-//	set.SAdd("set2", "member2", "member3", "member4")
-//	count := set.SInterStore("resultSet", "set1", "set2")
-//
-// In this example, it calculates the intersection of "set1" and "set2" and stores the result in "resultSet."
-// The resulting intersection set contains "member2" and "member3," and 'count' will be 2.
-func (s *Set) SInterStore(storeKey string, keys ...string) int {
-	if s.exists(storeKey) {
-		delete(s.records, storeKey)
+//	set.SAdd("set1", "member1", "member2")
+//	set.SAdd("set2", "member2", "member3")
+//	result := set.SUnionParallel("set1", "set2")
+//
+// In this example, 'result' holds "member1," "member2" and "member3," as with SUnion.
+func (s *Set) SUnionParallel(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	chunks := min(runtime.GOMAXPROCS(0), len(keys))
+	if chunks <= 1 {
+		return s.unionMembers(keys...)
 	}
 
-	intersection := s.SInter(keys...)
+	partials := make([]set, chunks)
+	chunkSize := (len(keys) + chunks - 1) / chunks
 
-	for _, interKey := range intersection {
-		s.SAdd(storeKey, interKey)
+	var wg sync.WaitGroup
+	for i := range partials {
+		chunk := keys[min(i*chunkSize, len(keys)):min((i+1)*chunkSize, len(keys))]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			partial := newSet()
+			for _, key := range chunk {
+				partial.SMerge(s.get(key))
+			}
+			partials[i] = partial
+		}()
+	}
+	wg.Wait()
+
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		result.SMerge(partial)
 	}
 
-	return len(intersection)
+	return result.list()
 }
 
 // existsInAll checks if an item exists in all given sets.
 func existsInAll(item interface{}, currentKey string, keys []string, s *Set) bool {
 	for _, key := range keys {
 		if key != currentKey {
-			nextSet, ok := s.records[key]
+			nextSet, ok := s.lookup(key)
 			if !ok || !nextSet.has(item) {
 				return false
 			}
@@ -620,6 +3535,37 @@ func existsInAll(item interface{}, currentKey string, keys []string, s *Set) boo
 	return true
 }
 
+// isComparable checks if the member can be used as a map key, that is if comparing it with == does not panic.
+// Slices, maps and functions cannot, nor can structs, arrays or interfaces holding them.
+func isComparable(member interface{}) bool {
+	switch member.(type) {
+	case nil, string, int, int64, float64, bool:
+		return true
+	}
+
+	return reflect.ValueOf(member).Comparable()
+}
+
+// inAllSets checks if an item exists in every one of the given sets.
+func inAllSets(item interface{}, sets []set) bool {
+	for _, currentSet := range sets {
+		if !currentSet.has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// inAnySet checks if an item exists in at least one of the given sets.
+func inAnySet(item interface{}, sets []set) bool {
+	for _, currentSet := range sets {
+		if currentSet.has(item) {
+			return true
+		}
+	}
+	return false
+}
+
 // add adds one or more items to the set.
 // if no items are provided, it has no effect.
 func (s set) add(items ...interface{}) {
@@ -675,25 +3621,37 @@ func (s set) copy() set {
 // list returns all items in the set as a slice.
 func (s set) list() []interface{} {
 	list := make([]interface{}, 0, len(s))
+	for item := range s {
+		list = append(list, item)
+	}
+
+	return list
+}
 
-	i := 0
+// sortedList returns all items in the set as a slice ordered by memberSortKey.
+func (s set) sortedList() []interface{} {
+	sortKeys := make(map[interface{}]string, len(s))
+	list := make([]interface{}, 0, len(s))
 	for item := range s {
-		list[i] = item
-		i++
+		sortKeys[item] = memberSortKey(item)
+		list = append(list, item)
 	}
 
+	sort.Slice(list, func(i, j int) bool {
+		return sortKeys[list[i]] < sortKeys[list[j]]
+	})
+
 	return list
 }
 
-// // foreach iterates over the items in the set and calls the provided function for each set member.
-// // the iteration continues until all items in the set have been visited or the closure returns false.
-// func (s set) foreach(callback func(item interface{}) bool) {
-// 	for item := range s {
-// 		if callback(item) {
-// 			break
-// 		}
-// 	}
-// }
+// foreach calls fn for each item in the set, until all items have been visited or fn returns false.
+func (s set) foreach(fn func(item interface{}) bool) {
+	for item := range s {
+		if !fn(item) {
+			return
+		}
+	}
+}
 
 // merge merges the current set with another set.
 // It is basically the implementation of the set union between 2 sets.
@@ -769,12 +3727,183 @@ func difference(sets ...set) set {
 	return resultSet
 }
 
-// exists checks if a key exists in the Set's records.
+// symDiff returns a new set containing the members present in an odd number of the sets
+// associated with the given keys. Each member toggles its presence in the result every time
+// it is seen, so members seen an even number of times cancel out. Non-existent keys are skipped.
+func (s *Set) symDiff(keys ...string) set {
+	resultSet := newSet()
+
+	for _, key := range keys {
+		currentSet, ok := s.lookup(key)
+		if !ok {
+			continue
+		}
+
+		for item := range currentSet {
+			if _, seen := resultSet[item]; seen {
+				delete(resultSet, item)
+			} else {
+				resultSet[item] = keyExists
+			}
+		}
+	}
+
+	return resultSet
+}
+
+// overlap returns a new set containing the members present in at least minCount of the sets
+// associated with the given keys. Non-existent keys are skipped.
+func (s *Set) overlap(minCount int, keys ...string) set {
+	counts := make(map[interface{}]int)
+	for _, key := range keys {
+		for item := range s.get(key) {
+			counts[item]++
+		}
+	}
+
+	resultSet := newSet()
+	for item, count := range counts {
+		if count >= minCount {
+			resultSet[item] = keyExists
+		}
+	}
+
+	return resultSet
+}
+
+// exists checks if a key exists in the Set's records. A key whose expiration has passed does not exist.
 func (s *Set) exists(key string) bool {
-	_, exist := s.records[key]
+	_, exist := s.lookup(key)
 	return exist
 }
 
+// lock acquires the write lock and records that the records may change. It also deletes the expired keys among
+// a sample of the keys holding an expiration, so that expired keys are eventually freed even if they are never
+// written again.
+func (s *Set) lock() {
+	s.mu.Lock()
+	s.version++
+	if len(s.expires) > 0 {
+		s.sweepExpired(s.now(), expireSweepSample)
+	}
+}
+
+// unlock releases the write lock acquired with lock, then reports the keys that expired meanwhile to the callbacks
+// registered with OnExpire.
+func (s *Set) unlock() {
+	expiries := s.takeExpiries()
+	s.mu.Unlock()
+	s.notifyExpiries(expiries)
+}
+
+// scanOrder returns the members of the set associated with the key in scan order. The order is cached until the
+// write lock is next acquired, so that a scan over many calls sorts the set once. The returned slice is shared
+// and must not be modified. The caller must hold the read lock.
+func (s *Set) scanOrder(key string) []interface{} {
+	if !s.exists(key) {
+		return nil
+	}
+
+	s.scanMu.Lock()
+	defer s.scanMu.Unlock()
+
+	if s.scanOrders == nil || s.scanVersion != s.version {
+		s.scanOrders = make(map[string][]interface{})
+		s.scanVersion = s.version
+	}
+
+	ordered, ok := s.scanOrders[key]
+	if !ok {
+		ordered = s.get(key).sortedList()
+		s.scanOrders[key] = ordered
+	}
+
+	return ordered
+}
+
+// lookup returns the set associated with the key, and whether the key exists.
+// A key whose expiration has passed is reported as non-existent, and its set is not returned.
+func (s *Set) lookup(key string) (set, bool) {
+	set, ok := s.records[key]
+	if !ok || s.expired(key) {
+		return nil, false
+	}
+
+	return set, true
+}
+
+// get returns the set associated with the key, or nil if the key does not exist.
+// Reading from the nil set behaves as reading from an empty one.
+func (s *Set) get(key string) set {
+	set, _ := s.lookup(key)
+	return set
+}
+
+// put associates the set with the key, replacing any previous set and clearing any expiration of the key.
+// The caller must hold the write lock.
+func (s *Set) put(key string, set set) {
+	if s.expired(key) {
+		s.recordExpiry(key)
+	}
+
+	s.records[key] = set
+	delete(s.expires, key)
+	delete(s.order, key)
+}
+
+// drop deletes the key, its set and its expiration. The caller must hold the write lock.
+func (s *Set) drop(key string) {
+	if s.expired(key) {
+		s.recordExpiry(key)
+	}
+
+	delete(s.records, key)
+	delete(s.expires, key)
+	delete(s.order, key)
+}
+
+// reset replaces every key and set by records, and clears every expiration. The caller must hold the write lock.
+func (s *Set) reset(records map[string]set) {
+	for key := range s.expires {
+		if s.expired(key) {
+			s.recordExpiry(key)
+		}
+	}
+
+	s.records = records
+	s.expires = make(map[string]time.Time)
+	s.expiryOrder = nil
+	if s.order != nil {
+		s.order = make(map[string]map[interface{}]uint64)
+	}
+}
+
+// expired reports whether the key has an expiration that has passed.
+func (s *Set) expired(key string) bool {
+	at, ok := s.expires[key]
+	return ok && !s.now().Before(at)
+}
+
+// store replaces the set associated with storeKey by result, and returns the size of result.
+// If result is empty, storeKey is deleted instead, so that store operations never leave an empty key behind.
+func (s *Set) store(storeKey string, result set) int {
+	if result.size() == 0 {
+		s.drop(storeKey)
+		return 0
+	}
+
+	s.put(storeKey, result)
+	return result.size()
+}
+
+// deleteIfEmpty deletes the key if its set has no members left, so that a key disappears
+// once its last member is removed, as in Redis.
+func (s *Set) deleteIfEmpty(key string) {
+	if set, ok := s.lookup(key); ok && set.size() == 0 {
+		s.drop(key)
+	}
+}
+
 // fieldExists checks if the specified member exists in the set associated with the given key.
 // If the key does not exist, it returns false.
 func (s *Set) fieldExists(key string, member interface{}) bool {
@@ -782,16 +3911,53 @@ func (s *Set) fieldExists(key string, member interface{}) bool {
 		return false
 	}
 
-	set := s.records[key]
+	set := s.get(key)
 	_, exists := set[member]
 
 	return exists
 }
 
-// randomElement returns a random element from the set.
-func randomElement(set set) interface{} {
-	for k := range set {
-		return k
+// memberString returns the string form of a member: the member itself for strings, its fmt.Sprint representation otherwise.
+func memberString(item interface{}) string {
+	if str, ok := item.(string); ok {
+		return str
+	}
+	return fmt.Sprint(item)
+}
+
+// memberSortKey returns a string used to order members deterministically,
+// made of the formatted value of the member followed by its type.
+func memberSortKey(item interface{}) string {
+	return fmt.Sprintf("%v\x00%T", item, item)
+}
+
+// candidates returns the members of the set to draw random members from. When the source of randomness was chosen
+// with WithRandSeed, WithRandSource or SetRandSource, they are ordered, so that the source yields reproducible
+// results regardless of the map iteration order. Otherwise they are left in map order, which spares formatting
+// and sorting the whole set on every draw. The caller must hold rngMu.
+func (s *Set) candidates(set set) []interface{} {
+	if s.seeded {
+		return set.sortedList()
 	}
-	return nil
+
+	return set.list()
+}
+
+// sample returns up to count distinct members of the set chosen uniformly at random, using a partial
+// Fisher-Yates shuffle.
+func (s *Set) sample(set set, count int) []interface{} {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+
+	members := s.candidates(set)
+	if count > len(members) {
+		count = len(members)
+	}
+
+	for i := 0; i < count; i++ {
+		j := i + s.rng.Intn(len(members)-i)
+		members[i], members[j] = members[j], members[i]
+	}
+
+	return members[:count]
 }
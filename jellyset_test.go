@@ -1,7 +1,14 @@
 package jellyset
 
 import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // Helper function to assert that a slice is empty.
@@ -126,9 +133,16 @@ func TestSet_SPop(t *testing.T) {
 		set.SAdd("myset", "member1", "member2", "member3", "member4", "member5")
 
 		popped := set.SPop("myset", 3)
-		expected := []interface{}{"member1", "member2", "member3"}
-		assertSlicesEqualIgnoreOrder(t, popped, expected, "Pop from Existing Set")
-
+		assertCountEqual(t, len(popped), 3)
+		assertSetSize(t, set, "myset", 2)
+
+		original := map[interface{}]bool{"member1": true, "member2": true, "member3": true, "member4": true, "member5": true}
+		for _, member := range popped {
+			if !original[member] {
+				t.Errorf("Expected popped member %v to come from the set", member)
+			}
+			assertKeyDoesNotExist(t, set.SIsMember("myset", member))
+		}
 	})
 
 	t.Run("Pop from Non-Existing Set", func(t *testing.T) {
@@ -155,6 +169,92 @@ func TestSet_SPop(t *testing.T) {
 		popped := set.SPop("myset", -1)
 		assertEmptySlice(t, popped)
 	})
+
+	t.Run("Pop More Elements than Set Size", func(t *testing.T) {
+		// Test popping more elements than the set holds.
+		// It ensures that only the existing members are returned, without nil padding, and the set is emptied.
+		set.SAdd("small", "a", "b", "c")
+		popped := set.SPop("small", 10)
+		assertSlicesEqualIgnoreOrder(t, popped, []interface{}{"a", "b", "c"}, "Pop More Elements than Set Size")
+		assertSetSize(t, set, "small", 0)
+	})
+
+	t.Run("Pop Exactly Set Size", func(t *testing.T) {
+		// Test popping exactly as many elements as the set holds.
+		// It ensures that every member is returned and the set is emptied.
+		set.SAdd("exact", "a", "b", "c")
+		popped := set.SPop("exact", 3)
+		assertSlicesEqualIgnoreOrder(t, popped, []interface{}{"a", "b", "c"}, "Pop Exactly Set Size")
+		assertSetSize(t, set, "exact", 0)
+	})
+
+	t.Run("Pop Fewer Elements than Set Size", func(t *testing.T) {
+		// Test popping fewer elements than the set holds.
+		// It ensures that the popped members are deleted from the set and the others are kept.
+		set.SAdd("large", "a", "b", "c", "d")
+		popped := set.SPop("large", 1)
+		assertCountEqual(t, len(popped), 1)
+		assertSetSize(t, set, "large", 3)
+		assertKeyDoesNotExist(t, set.SIsMember("large", popped[0]))
+	})
+}
+
+func TestSet_STrim(t *testing.T) {
+	t.Run("Shrink Below Current Size", func(t *testing.T) {
+		// Test trimming a set to fewer members than it holds.
+		// It ensures that the surplus is removed, counted, and that the members left come from the set.
+		set := New()
+		set.SAdd("myset", "a", "b", "c", "d", "e")
+
+		assertCountEqual(t, set.STrim("myset", 2), 3)
+		assertSetSize(t, set, "myset", 2)
+		for _, member := range set.SMembers("myset") {
+			if !strings.Contains("abcde", member.(string)) {
+				t.Errorf("Expected the remaining members to come from the set, but got %v", member)
+			}
+		}
+	})
+
+	t.Run("At and Above Current Size", func(t *testing.T) {
+		// Test trimming a set to exactly its size and to more than its size.
+		// It ensures that nothing is removed in either case.
+		set := New()
+		set.SAdd("myset", "a", "b", "c")
+
+		assertCountEqual(t, set.STrim("myset", 3), 0)
+		assertCountEqual(t, set.STrim("myset", 10), 0)
+		assertSetSize(t, set, "myset", 3)
+	})
+
+	t.Run("Trim to Zero", func(t *testing.T) {
+		// Test trimming a set to 0 members, and a non-existent key.
+		// It ensures that the key is deleted, and that a non-existent key is left alone.
+		set := New()
+		set.SAdd("myset", "a", "b", "c")
+
+		assertCountEqual(t, set.STrim("myset", 0), 3)
+		assertKeyDoesNotExist(t, set.SKeyExists("myset"))
+		assertCountEqual(t, set.STrim("nonexistent", 0), 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("nonexistent"))
+	})
+
+	t.Run("Reproducible When Seeded", func(t *testing.T) {
+		// Test trimming the same set on two Sets seeded alike.
+		// It ensures that the same members are kept.
+		members := []interface{}{}
+		for i := 0; i < 50; i++ {
+			members = append(members, i)
+		}
+
+		set1 := New(WithRandSeed(42))
+		set2 := New(WithRandSeed(42))
+		set1.SAddSlice("myset", members)
+		set2.SAddSlice("myset", members)
+		set1.STrim("myset", 10)
+		set2.STrim("myset", 10)
+
+		assertSlicesEqualIgnoreOrder(t, set1.SMembers("myset"), set2.SMembers("myset"), "Reproducible When Seeded")
+	})
 }
 
 func TestSet_SRem(t *testing.T) {
@@ -165,9 +265,8 @@ func TestSet_SRem(t *testing.T) {
 		// It checks if the specified member is removed.
 		set.SAdd("myset", "member1", "member2", "member3")
 		removed := set.SRem("myset", "member2")
-		if !removed {
-			t.Errorf("Expected to remove 'member2' from the set, but it was not removed")
-		}
+		assertCountEqual(t, removed, 1)
+		assertKeyDoesNotExist(t, set.SIsMember("myset", "member2"))
 	})
 
 	t.Run("Remove Non-Existent Member", func(t *testing.T) {
@@ -175,18 +274,32 @@ func TestSet_SRem(t *testing.T) {
 		// It ensures that the removal operation doesn't affect the set.
 		set.SAdd("myset", "member1", "member3")
 		removed := set.SRem("myset", "nonexistent")
-		if removed {
-			t.Errorf("Expected not to remove 'nonexistent' from the set, but it was removed")
-		}
+		assertCountEqual(t, removed, 0)
+		assertSetSize(t, set, "myset", 2)
 	})
 
 	t.Run("Remove from Non-Existent Set", func(t *testing.T) {
 		// Test removing a member from a non-existent set.
 		// It checks that removal doesn't occur when the set doesn't exist.
-		removed := set.SRem("nonexistent", "member1")
-		if removed {
-			t.Errorf("Expected not to remove from a non-existent set, but removal occurred")
-		}
+		removed := set.SRem("nonexistent", "member1", "member2")
+		assertCountEqual(t, removed, 0)
+	})
+
+	t.Run("Remove Multiple Members", func(t *testing.T) {
+		// Test removing several members at once, some of which are not in the set.
+		// It ensures that only the members that existed are counted.
+		set.SAdd("multi", "a", "b", "c", "d")
+		removed := set.SRem("multi", "a", "c", "x", "a")
+		assertCountEqual(t, removed, 2)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("multi"), []interface{}{"b", "d"}, "Remove Multiple Members")
+	})
+
+	t.Run("Remove No Members", func(t *testing.T) {
+		// Test removing with an empty list of members.
+		// It ensures that nothing is removed.
+		removed := set.SRem("multi")
+		assertCountEqual(t, removed, 0)
+		assertSetSize(t, set, "multi", 2)
 	})
 }
 
@@ -222,6 +335,40 @@ func TestSet_SRandMember(t *testing.T) {
 			t.Errorf("Expected to retrieve 0 random members, but got %d", len(randomMembers))
 		}
 	})
+
+	t.Run("Negative Count Larger than Set", func(t *testing.T) {
+		// Test retrieving random members with a negative count larger than the set.
+		// It ensures that exactly abs(count) members are returned, all of them from the set.
+		set.SAdd("small", "a", "b", "c")
+		randomMembers := set.SRandMember("small", -10)
+		assertCountEqual(t, len(randomMembers), 10)
+		for _, member := range randomMembers {
+			assertKeyExists(t, set.SIsMember("small", member))
+		}
+		assertSetSize(t, set, "small", 3)
+	})
+
+	t.Run("Negative Count on Single-Element Set", func(t *testing.T) {
+		// Test retrieving random members with a negative count from a single-element set.
+		// It ensures that the only member is repeated abs(count) times.
+		set.SAdd("single", "only")
+		randomMembers := set.SRandMember("single", -3)
+		assertSlicesEqual(t, randomMembers, []interface{}{"only", "only", "only"})
+	})
+
+	t.Run("Negative Count on Non-Existent Set", func(t *testing.T) {
+		// Test retrieving random members with a negative count from a non-existent set.
+		// It ensures that an empty slice is returned.
+		assertEmptySlice(t, set.SRandMember("nonexistent", -3))
+	})
+
+	t.Run("Negative Count of Huge Magnitude", func(t *testing.T) {
+		// Test retrieving random members with math.MinInt and with a count far beyond the cap.
+		// It ensures that the result is capped at RandMemberMaxRepeats members instead of panicking or exhausting memory.
+		set.SAdd("single", "only")
+		assertCountEqual(t, len(set.SRandMember("single", math.MinInt)), RandMemberMaxRepeats)
+		assertCountEqual(t, len(set.SRandMember("single", -1<<40)), RandMemberMaxRepeats)
+	})
 }
 
 func Test_SMove(t *testing.T) {
@@ -258,6 +405,65 @@ func Test_SMove(t *testing.T) {
 		assertKeyExists(t, moved)
 	})
 }
+func TestSet_SMoveIfAbsent(t *testing.T) {
+	t.Run("Member Absent in Destination", func(t *testing.T) {
+		// Test moving a member the destination set does not hold.
+		// It ensures that the member is moved, and reported both as moved and as removed from the source.
+		set := New()
+		set.SAdd("src", "a", "b")
+
+		moved, removed := set.SMoveIfAbsent("src", "dest", "a")
+		assertKeyExists(t, moved)
+		assertKeyExists(t, removed)
+		assertKeyDoesNotExist(t, set.SIsMember("src", "a"))
+		assertKeyExists(t, set.SIsMember("dest", "a"))
+	})
+
+	t.Run("Member Present in Destination", func(t *testing.T) {
+		// Test moving a member the destination set already holds, out of a source set holding only it.
+		// It ensures that the member is only removed from the source, whose key is deleted, and not reported as moved.
+		set, log := observedSet()
+		set.SAdd("src", "a")
+		set.SAdd("dest", "a", "b")
+		*log = nil
+
+		moved, removed := set.SMoveIfAbsent("src", "dest", "a")
+		assertKeyDoesNotExist(t, moved)
+		assertKeyExists(t, removed)
+		assertKeyDoesNotExist(t, set.SKeyExists("src"))
+		assertSetSize(t, set, "dest", 2)
+		assertSlicesEqual(t, *log, []interface{}{"-src:a"})
+	})
+
+	t.Run("Member Absent in Source", func(t *testing.T) {
+		// Test moving a member the source set does not hold, out of an existing and a non-existent source.
+		// It ensures that nothing changes.
+		set := New()
+		set.SAdd("src", "a")
+		set.SAdd("dest", "b")
+
+		for _, src := range []string{"src", "nonexistent"} {
+			moved, removed := set.SMoveIfAbsent(src, "dest", "b")
+			assertKeyDoesNotExist(t, moved)
+			assertKeyDoesNotExist(t, removed)
+		}
+		assertSetSize(t, set, "src", 1)
+		assertSetSize(t, set, "dest", 1)
+	})
+
+	t.Run("Same Source and Destination", func(t *testing.T) {
+		// Test moving a member onto the set it is already in.
+		// It ensures that the member is kept.
+		set := New()
+		set.SAdd("myset", "a")
+
+		moved, removed := set.SMoveIfAbsent("myset", "myset", "a")
+		assertKeyDoesNotExist(t, moved)
+		assertKeyDoesNotExist(t, removed)
+		assertKeyExists(t, set.SIsMember("myset", "a"))
+	})
+}
+
 func TestSet_SCard(t *testing.T) {
 	set := New()
 
@@ -480,6 +686,43 @@ func TestSet_SUnionStore(t *testing.T) {
 		assertCountEqual(t, count, 7)
 	})
 
+	t.Run("Union Store Overwrites Existing Destination", func(t *testing.T) {
+		// Test the union store operation with a destination that already holds unrelated members.
+		// It ensures that the destination is replaced by the union rather than appended to.
+		s := New()
+		s.SAdd("set1", "a", "b")
+		s.SAdd("set2", "b", "c")
+		s.SAdd("dest", "stale1", "stale2")
+
+		count := s.SUnionStore("dest", "set1", "set2")
+		assertCountEqual(t, count, 3)
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("dest"), []interface{}{"a", "b", "c"}, "Union Store Overwrites Existing Destination")
+	})
+
+	t.Run("Union Store with Empty Result Deletes Destination", func(t *testing.T) {
+		// Test the union store operation with an empty union and an existing destination.
+		// It ensures that the destination is deleted instead of being left with its old members.
+		s := New()
+		s.SAdd("dest", "stale")
+
+		count := s.SUnionStore("dest", "nonexistent_set1", "nonexistent_set2")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, s.SKeyExists("dest"))
+	})
+
+	t.Run("Union Store into a Source Key", func(t *testing.T) {
+		// Test the union store operation when the destination is also one of the source keys.
+		// It ensures that the stored result matches the one computed into a separate key.
+		s := New()
+		s.SAdd("set1", "a", "b")
+		s.SAdd("set2", "b", "c")
+		expected := s.SUnionStore("separate", "set1", "set2")
+
+		count := s.SUnionStore("set1", "set1", "set2")
+		assertCountEqual(t, count, expected)
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), []interface{}{"a", "b", "c"}, "Union Store into a Source Key")
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), s.SMembers("separate"), "Union Store into a Source Key")
+	})
 }
 
 func TestSet_SDiff(t *testing.T) {
@@ -501,12 +744,12 @@ func TestSet_SDiff(t *testing.T) {
 	})
 
 	t.Run("Difference with Empty Set", func(t *testing.T) {
-		// Test the set difference operation with an empty set.
-		// It verifies that the result set is also an empty set, and the empty set is ignored.
+		// Test the set difference operation with a set created without members.
+		// It verifies that no key is created for the empty set, so the difference is empty as for a non-existent key.
 		set.SAdd("set1", "a", "b", "c")
 		set.SAdd("empty_set")
-		result := set.SDiff("set1", "empty_set")
-		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Difference with Empty Set")
+		assertKeyDoesNotExist(t, set.SKeyExists("empty_set"))
+		assertEmptySlice(t, set.SDiff("set1", "empty_set"))
 	})
 
 	t.Run("Set Difference of Non-Empty Sets", func(t *testing.T) {
@@ -540,13 +783,13 @@ func TestSet_SDiffStore(t *testing.T) {
 	})
 
 	t.Run("Difference Store with Empty Set", func(t *testing.T) {
-		// Test the difference store operation with an empty set.
-		// It verifies that the result set is also an empty set, and the empty set is ignored.
+		// Test the difference store operation with a set created without members.
+		// It verifies that no key is created for the empty set, so nothing is stored as for a non-existent key.
 		set.SAdd("set1", "a", "b", "c")
 		set.SAdd("empty_set")
 		count := set.SDiffStore("result", "set1", "empty_set")
-		assertSetSize(t, set, "result", 3)
-		assertCountEqual(t, count, 3)
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+		assertCountEqual(t, count, 0)
 	})
 
 	t.Run("Difference Store of Non-Empty Sets", func(t *testing.T) {
@@ -557,6 +800,99 @@ func TestSet_SDiffStore(t *testing.T) {
 		count := set.SDiffStore("result", "set1", "set2")
 		assertCountEqual(t, count, 2)
 	})
+
+	t.Run("Difference Store Overwrites Existing Destination", func(t *testing.T) {
+		// Test the difference store operation with a destination that already holds unrelated members.
+		// It ensures that the destination is replaced by the difference rather than appended to.
+		s := New()
+		s.SAdd("set1", "a", "b", "c")
+		s.SAdd("set2", "c")
+		s.SAdd("dest", "stale1", "stale2")
+
+		count := s.SDiffStore("dest", "set1", "set2")
+		assertCountEqual(t, count, 2)
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("dest"), []interface{}{"a", "b"}, "Difference Store Overwrites Existing Destination")
+	})
+
+	t.Run("Difference Store with Empty Result Deletes Destination", func(t *testing.T) {
+		// Test the difference store operation with an empty difference and an existing destination.
+		// It ensures that the destination is deleted instead of being left with its old members.
+		s := New()
+		s.SAdd("set1", "a")
+		s.SAdd("set2", "a")
+		s.SAdd("dest", "stale")
+
+		count := s.SDiffStore("dest", "set1", "set2")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, s.SKeyExists("dest"))
+	})
+
+	t.Run("Difference Store into a Source Key", func(t *testing.T) {
+		// Test the difference store operation when the destination is also one of the source keys.
+		// It ensures that the stored result matches the one computed into a separate key.
+		s := New()
+		s.SAdd("set1", "a", "b", "c")
+		s.SAdd("set2", "b", "d")
+		expected := s.SDiffStore("separate", "set1", "set2")
+
+		count := s.SDiffStore("set1", "set1", "set2")
+		assertCountEqual(t, count, expected)
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), []interface{}{"a", "c"}, "Difference Store into a Source Key")
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), s.SMembers("separate"), "Difference Store into a Source Key")
+	})
+}
+
+func TestSet_SDiffBaselineStore(t *testing.T) {
+	populated := func() *Set {
+		set := New()
+		set.SAdd("baseline", "a", "b", "c")
+		set.SAdd("set1", "a", "x")
+		set.SAdd("set2", "b", "c", "y", "z")
+		set.SAdd("set3", "a", "b")
+		return set
+	}
+
+	t.Run("Baseline Overlapping Several Keys", func(t *testing.T) {
+		// Test subtracting a baseline from sets it overlaps partially and fully.
+		// It ensures that each derived set holds its own difference, and that a fully covered set yields no derived key.
+		set := populated()
+		sizes := set.SDiffBaselineStore("baseline", []string{"set1", "set2", "set3"}, "new:")
+
+		assertCountEqual(t, len(sizes), 3)
+		assertCountEqual(t, sizes["set1"], 1)
+		assertCountEqual(t, sizes["set2"], 2)
+		assertCountEqual(t, sizes["set3"], 0)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("new:set1"), []interface{}{"x"}, "Baseline Overlapping Several Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("new:set2"), []interface{}{"y", "z"}, "Baseline Overlapping Several Keys")
+		assertKeyDoesNotExist(t, set.SKeyExists("new:set3"))
+		assertSetSize(t, set, "set1", 2)
+	})
+
+	t.Run("Matches SDiff", func(t *testing.T) {
+		// Test subtracting a baseline from an existing and a non-existent key, and a non-existent baseline.
+		// It ensures that every derived set matches the corresponding SDiff.
+		set := populated()
+		for _, baseline := range []string{"baseline", "nonexistent"} {
+			keys := []string{"set1", "set2", "missing"}
+			sizes := set.SDiffBaselineStore(baseline, keys, "d:")
+			for _, key := range keys {
+				expected := set.SDiff(key, baseline)
+				assertCountEqual(t, sizes[key], len(expected))
+				assertSlicesEqualIgnoreOrder(t, set.SMembers("d:"+key), expected, "Matches SDiff")
+			}
+		}
+	})
+
+	t.Run("Derived Key Is a Source Key", func(t *testing.T) {
+		// Test an empty prefix, so that each difference replaces its own source set.
+		// It ensures that every difference is computed from the original sets.
+		set := populated()
+		set.SDiffBaselineStore("baseline", []string{"set1", "set2"}, "")
+
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"x"}, "Derived Key Is a Source Key")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set2"), []interface{}{"y", "z"}, "Derived Key Is a Source Key")
+		assertSetSize(t, set, "baseline", 3)
+	})
 }
 
 func TestSet_SInter(t *testing.T) {
@@ -604,6 +940,35 @@ func TestSet_SInter(t *testing.T) {
 		result := set.SInter("set1", "set2")
 		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"c", "d"}, "Intersection with Duplicate Elements")
 	})
+
+	t.Run("Missing Key Among Several", func(t *testing.T) {
+		// Test the set intersection operation with a missing key first, in the middle, and last.
+		// It ensures that the result is empty wherever the missing key appears.
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", "a", "b", "c")
+		assertEmptySlice(t, set.SInter("nonexistent_set", "set1", "set2"))
+		assertEmptySlice(t, set.SInter("set1", "nonexistent_set", "set2"))
+		assertEmptySlice(t, set.SInter("set1", "set2", "nonexistent_set"))
+	})
+
+	t.Run("One Existing Empty Set", func(t *testing.T) {
+		// Test the set intersection operation with an existing empty set among non-empty ones.
+		// It ensures that the empty set makes the intersection empty, wherever it appears.
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", "a", "b", "c")
+		set.SAdd("empty_set")
+		assertEmptySlice(t, set.SInter("empty_set", "set1", "set2"))
+		assertEmptySlice(t, set.SInter("set1", "set2", "empty_set"))
+	})
+
+	t.Run("All Empty Inputs", func(t *testing.T) {
+		// Test the set intersection operation with only empty or missing sets, and with no key at all.
+		// It ensures that each call returns an empty result without panicking.
+		set.SAdd("empty_set")
+		assertEmptySlice(t, set.SInter("empty_set", "empty_set"))
+		assertEmptySlice(t, set.SInter("empty_set", "nonexistent_set"))
+		assertEmptySlice(t, set.SInter())
+	})
 }
 
 func TestSet_SInterStore(t *testing.T) {
@@ -663,4 +1028,2972 @@ func TestSet_SInterStore(t *testing.T) {
 		assertCountEqual(t, count, 2)
 		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"c", "d"}, "Intersection Store with Overwriting Existing Set")
 	})
+
+	t.Run("Intersection Store with Empty Result Deletes Destination", func(t *testing.T) {
+		// Test the intersection store operation with an empty intersection and an existing destination.
+		// It ensures that the destination is deleted instead of being left with its old members.
+		s := New()
+		s.SAdd("set1", "a")
+		s.SAdd("set2", "b")
+		s.SAdd("dest", "stale")
+
+		count := s.SInterStore("dest", "set1", "set2")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, s.SKeyExists("dest"))
+	})
+
+	t.Run("Intersection Store into a Source Key", func(t *testing.T) {
+		// Test the intersection store operation when the destination is also one of the source keys.
+		// It ensures that the stored result matches the one computed into a separate key.
+		s := New()
+		s.SAdd("set1", "a", "b", "c")
+		s.SAdd("set2", "b", "c", "d")
+		expected := s.SInterStore("separate", "set1", "set2")
+
+		count := s.SInterStore("set1", "set1", "set2")
+		assertCountEqual(t, count, expected)
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), []interface{}{"b", "c"}, "Intersection Store into a Source Key")
+		assertSlicesEqualIgnoreOrder(t, s.SMembers("set1"), s.SMembers("separate"), "Intersection Store into a Source Key")
+	})
+}
+
+func TestSet_SDuplicate(t *testing.T) {
+	set := New()
+
+	t.Run("Deep Duplicate is Independent", func(t *testing.T) {
+		// Test duplicating a set with a deep copy.
+		// It ensures that mutations on either key do not affect the other.
+		set.SAdd("source", "a", "b", "c")
+		duplicated := set.SDuplicate("source", "deep", true)
+		assertKeyExists(t, duplicated)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("deep"), []interface{}{"a", "b", "c"}, "Deep Duplicate is Independent")
+
+		set.SAdd("source", "d")
+		set.SAdd("deep", "e")
+		assertKeyDoesNotExist(t, set.SIsMember("deep", "d"))
+		assertKeyDoesNotExist(t, set.SIsMember("source", "e"))
+		assertSetSize(t, set, "source", 4)
+		assertSetSize(t, set, "deep", 4)
+	})
+
+	t.Run("Shallow Duplicate Shares Mutations", func(t *testing.T) {
+		// Test duplicating a set without copying it.
+		// It ensures that mutations made through one key are visible through the other.
+		set.SAdd("aliased", "a", "b")
+		duplicated := set.SDuplicate("aliased", "shallow", false)
+		assertKeyExists(t, duplicated)
+
+		set.SAdd("aliased", "c")
+		set.SRem("shallow", "a")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("shallow"), []interface{}{"b", "c"}, "Shallow Duplicate Shares Mutations")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("aliased"), []interface{}{"b", "c"}, "Shallow Duplicate Shares Mutations")
+	})
+
+	t.Run("Emptying a Shallow Source Empties the Alias", func(t *testing.T) {
+		// Test removing every member of the source of a shallow duplicate.
+		// It ensures that the source is deleted while the alias is left as an existing, empty key, as documented.
+		set.SAdd("emptied", "a", "b")
+		set.SDuplicate("emptied", "alias", false)
+		set.SRem("emptied", "a")
+		set.SPop("emptied", 1)
+
+		assertKeyDoesNotExist(t, set.SKeyExists("emptied"))
+		assertKeyExists(t, set.SKeyExists("alias"))
+		assertSetSize(t, set, "alias", 0)
+	})
+
+	t.Run("Duplicate Non-Existent Source", func(t *testing.T) {
+		// Test duplicating a set that doesn't exist.
+		// It ensures that false is returned and the destination is not created.
+		duplicated := set.SDuplicate("nonexistent", "dest", true)
+		assertKeyDoesNotExist(t, duplicated)
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+	})
+}
+
+func TestSet_SSymDiff(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "b", "c", "d")
+	set.SAdd("set3", "c", "d", "e")
+
+	t.Run("Symmetric Difference of Two Overlapping Sets", func(t *testing.T) {
+		// Test the symmetric difference of two overlapping sets.
+		// It ensures that the result equals the union of the sets minus their intersection.
+		result := set.SSymDiff("set1", "set2")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "d"}, "Symmetric Difference of Two Overlapping Sets")
+
+		set.SUnionStore("union", "set1", "set2")
+		set.SInterStore("intersection", "set1", "set2")
+		assertSlicesEqualIgnoreOrder(t, result, set.SDiff("union", "intersection"), "Symmetric Difference of Two Overlapping Sets")
+	})
+
+	t.Run("Symmetric Difference of Three Sets", func(t *testing.T) {
+		// Test the symmetric difference across three sets.
+		// It ensures that only members present in an odd number of the sets are returned.
+		result := set.SSymDiff("set1", "set2", "set3")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "c", "e"}, "Symmetric Difference of Three Sets")
+	})
+
+	t.Run("Symmetric Difference with Non-Existent Sets", func(t *testing.T) {
+		// Test the symmetric difference with keys that don't exist.
+		// It ensures that non-existent keys contribute nothing to the result.
+		result := set.SSymDiff("set1", "nonexistent", "set2")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "d"}, "Symmetric Difference with Non-Existent Sets")
+		assertEmptySlice(t, set.SSymDiff("nonexistent1", "nonexistent2"))
+	})
+}
+
+func TestSet_SSymDiffStore(t *testing.T) {
+	set := New()
+
+	t.Run("Symmetric Difference Store of Two Sets", func(t *testing.T) {
+		// Test the symmetric difference store operation between two overlapping sets.
+		// It ensures that the stored contents and the returned count match SSymDiff.
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "b", "c", "d")
+		expected := set.SSymDiff("set1", "set2")
+
+		count := set.SSymDiffStore("result", "set1", "set2")
+		assertCountEqual(t, count, len(expected))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"a", "d"}, "Symmetric Difference Store of Two Sets")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), expected, "Symmetric Difference Store of Two Sets")
+	})
+
+	t.Run("Symmetric Difference Store of Three Sets", func(t *testing.T) {
+		// Test the symmetric difference store operation across three sets.
+		// It ensures that only members present in an odd number of sets are stored.
+		set.SAdd("set3", "c", "d", "e")
+		expected := set.SSymDiff("set1", "set2", "set3")
+
+		count := set.SSymDiffStore("result", "set1", "set2", "set3")
+		assertCountEqual(t, count, len(expected))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"a", "c", "e"}, "Symmetric Difference Store of Three Sets")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), expected, "Symmetric Difference Store of Three Sets")
+	})
+
+	t.Run("Symmetric Difference Store Overwrites and Deletes Empty Result", func(t *testing.T) {
+		// Test the symmetric difference store operation on identical sets.
+		// It ensures that an existing destination is deleted when the result is empty.
+		set.SAdd("same1", "x", "y")
+		set.SAdd("same2", "x", "y")
+		set.SAdd("result", "existing")
+
+		count := set.SSymDiffStore("result", "same1", "same2")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+}
+
+func TestSet_SMembersWithPrefix(t *testing.T) {
+	set := New()
+	set.SAdd("terms", "apple", "apricot", "banana", "grape", 42, 3.14, true)
+
+	t.Run("Members Matching Prefix", func(t *testing.T) {
+		// Test retrieving the string members starting with a prefix.
+		// It ensures that non-matching strings and non-string members are ignored.
+		result := set.SMembersWithPrefix("terms", "ap")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"apple", "apricot"}, "Members Matching Prefix")
+	})
+
+	t.Run("No Members Matching Prefix", func(t *testing.T) {
+		// Test retrieving members with a prefix that matches nothing.
+		// It ensures that an empty slice is returned.
+		result := set.SMembersWithPrefix("terms", "zz")
+		assertEmptySlice(t, result)
+	})
+
+	t.Run("Empty Prefix", func(t *testing.T) {
+		// Test retrieving members with an empty prefix.
+		// It ensures that every string member is returned and non-string members are ignored.
+		result := set.SMembersWithPrefix("terms", "")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"apple", "apricot", "banana", "grape"}, "Empty Prefix")
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test retrieving members from a non-existent set.
+		// It ensures that an empty slice is returned.
+		result := set.SMembersWithPrefix("nonexistent", "ap")
+		assertEmptySlice(t, result)
+	})
+}
+
+func TestSet_SCASMember(t *testing.T) {
+	set := New()
+
+	t.Run("Swap Present Member", func(t *testing.T) {
+		// Test swapping a member that is present in the set.
+		// It ensures that the old member is replaced with the new one.
+		set.SAdd("state", "pending")
+		swapped := set.SCASMember("state", "pending", "running")
+		assertKeyExists(t, swapped)
+		assertSlicesEqual(t, set.SMembers("state"), []interface{}{"running"})
+	})
+
+	t.Run("Swap Absent Member", func(t *testing.T) {
+		// Test swapping a member that is not in the set.
+		// It ensures that the set is left untouched.
+		swapped := set.SCASMember("state", "pending", "failed")
+		assertKeyDoesNotExist(t, swapped)
+		assertSlicesEqual(t, set.SMembers("state"), []interface{}{"running"})
+	})
+
+	t.Run("Swap Member With Itself", func(t *testing.T) {
+		// Test swapping a member with itself.
+		// It ensures that the swap succeeds and the member is kept.
+		swapped := set.SCASMember("state", "running", "running")
+		assertKeyExists(t, swapped)
+		assertSlicesEqual(t, set.SMembers("state"), []interface{}{"running"})
+	})
+
+	t.Run("Swap in Non-Existent Set", func(t *testing.T) {
+		// Test swapping a member in a non-existent set.
+		// It ensures that false is returned and no set is created.
+		swapped := set.SCASMember("nonexistent", "pending", "running")
+		assertKeyDoesNotExist(t, swapped)
+		assertKeyDoesNotExist(t, set.SKeyExists("nonexistent"))
+	})
+
+	t.Run("Swap With Invalid Member", func(t *testing.T) {
+		// Test swapping with members that cannot be map keys, as the old or the new member.
+		// It ensures that false is returned without panicking and that the set is left untouched.
+		assertKeyDoesNotExist(t, set.SCASMember("state", "running", []int{1}))
+		assertKeyDoesNotExist(t, set.SCASMember("state", []int{1}, "failed"))
+		assertSlicesEqual(t, set.SMembers("state"), []interface{}{"running"})
+	})
+}
+
+func TestSet_SHashJoin(t *testing.T) {
+	type order struct{ ID, Amount int }
+	type shipment struct{ OrderID int }
+
+	set := New()
+	set.SAdd("orders", order{ID: 1, Amount: 10}, order{ID: 1, Amount: 20}, order{ID: 2, Amount: 30})
+	set.SAdd("shipments", shipment{OrderID: 1}, shipment{OrderID: 3})
+
+	keyFn := func(item interface{}) interface{} {
+		switch v := item.(type) {
+		case order:
+			return v.ID
+		case shipment:
+			return v.OrderID
+		}
+		return nil
+	}
+
+	t.Run("Join on Overlapping and Non-Overlapping Keys", func(t *testing.T) {
+		// Test joining two sets on a key shared by some of their members.
+		// It ensures that members are grouped per join key on the correct side.
+		joined := set.SHashJoin("orders", "shipments", keyFn)
+		assertCountEqual(t, len(joined), 3)
+
+		assertSlicesEqualIgnoreOrder(t, joined[1][0], []interface{}{order{ID: 1, Amount: 10}, order{ID: 1, Amount: 20}}, "Join Key 1 Orders")
+		assertSlicesEqualIgnoreOrder(t, joined[1][1], []interface{}{shipment{OrderID: 1}}, "Join Key 1 Shipments")
+
+		assertSlicesEqualIgnoreOrder(t, joined[2][0], []interface{}{order{ID: 2, Amount: 30}}, "Join Key 2 Orders")
+		assertEmptySlice(t, joined[2][1])
+
+		assertEmptySlice(t, joined[3][0])
+		assertSlicesEqualIgnoreOrder(t, joined[3][1], []interface{}{shipment{OrderID: 3}}, "Join Key 3 Shipments")
+	})
+
+	t.Run("Join with Non-Existent Set", func(t *testing.T) {
+		// Test joining a set with a non-existent set.
+		// It ensures that only the existing set contributes members.
+		joined := set.SHashJoin("nonexistent", "shipments", keyFn)
+		assertCountEqual(t, len(joined), 2)
+		assertEmptySlice(t, joined[1][0])
+		assertSlicesEqualIgnoreOrder(t, joined[1][1], []interface{}{shipment{OrderID: 1}}, "Join with Non-Existent Set")
+	})
+
+	t.Run("Join Two Non-Existent Sets", func(t *testing.T) {
+		// Test joining two non-existent sets.
+		// It ensures that an empty map is returned.
+		joined := set.SHashJoin("nonexistent1", "nonexistent2", keyFn)
+		assertCountEqual(t, len(joined), 0)
+	})
+}
+
+func TestSet_AllCardinalities(t *testing.T) {
+	set := New()
+
+	t.Run("Cardinalities of Empty Set", func(t *testing.T) {
+		// Test retrieving the cardinalities of a Set without keys.
+		// It ensures that an empty map is returned.
+		assertCountEqual(t, len(set.AllCardinalities()), 0)
+	})
+
+	t.Run("Cardinalities of Several Keys", func(t *testing.T) {
+		// Test retrieving the cardinalities of several keys of varied sizes, after adding no members to another key.
+		// It ensures that the returned map matches SCard for every key, and that no empty key is reported.
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "d")
+		set.SAdd("set3", 1, 2, 3, 4, 5)
+		set.SAdd("empty_set")
+
+		cardinalities := set.AllCardinalities()
+		assertCountEqual(t, len(cardinalities), 3)
+		for _, key := range []string{"set1", "set2", "set3"} {
+			size, ok := cardinalities[key]
+			assertKeyExists(t, ok)
+			assertCountEqual(t, size, set.SCard(key))
+		}
+		_, ok := cardinalities["empty_set"]
+		assertKeyDoesNotExist(t, ok)
+	})
+}
+
+func TestSet_SReverseDiffStore(t *testing.T) {
+	set := New()
+	set.SAdd("base", "a", "b", "c")
+	set.SAdd("other1", "a", "d")
+	set.SAdd("other2", "b", "e", "f")
+	set.SAdd("other3", "c")
+
+	t.Run("Reverse Difference Store of Several Sets", func(t *testing.T) {
+		// Test storing the members of the other sets that are absent from the base set.
+		// It ensures that the stored result and the count are correct.
+		count := set.SReverseDiffStore("result", "base", "other1", "other2", "other3")
+		assertCountEqual(t, count, 3)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"d", "e", "f"}, "Reverse Difference Store of Several Sets")
+	})
+
+	t.Run("Reverse Difference Store with Non-Existent Base", func(t *testing.T) {
+		// Test storing the reverse difference against a non-existent base set.
+		// It ensures that the union of the other sets is stored.
+		count := set.SReverseDiffStore("result", "nonexistent", "other1", "other3")
+		assertCountEqual(t, count, 3)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"a", "c", "d"}, "Reverse Difference Store with Non-Existent Base")
+	})
+
+	t.Run("Reverse Difference Store Deletes Empty Result", func(t *testing.T) {
+		// Test storing a reverse difference that is empty.
+		// It ensures that the existing destination is deleted.
+		set.SAdd("result", "existing")
+		count := set.SReverseDiffStore("result", "base", "other3", "nonexistent")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+}
+
+func TestSet_SContainsMap(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Present, Absent and Duplicate Candidates", func(t *testing.T) {
+		// Test checking a mix of present, absent and duplicate candidates.
+		// It ensures that every candidate is mapped to its membership and duplicates collapse.
+		found := set.SContainsMap("myset", []interface{}{"a", "x", "c", "a", "x"})
+		assertCountEqual(t, len(found), 3)
+		assertKeyExists(t, found["a"])
+		assertKeyExists(t, found["c"])
+		assertKeyDoesNotExist(t, found["x"])
+		if _, ok := found["x"]; !ok {
+			t.Errorf("Expected the absent candidate to be present in the map")
+		}
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test checking candidates against a non-existent set.
+		// It ensures that every candidate is mapped to false.
+		found := set.SContainsMap("nonexistent", []interface{}{"a", "b"})
+		assertCountEqual(t, len(found), 2)
+		assertKeyDoesNotExist(t, found["a"])
+		assertKeyDoesNotExist(t, found["b"])
+	})
+
+	t.Run("No Candidates", func(t *testing.T) {
+		// Test checking an empty list of candidates.
+		// It ensures that an empty map is returned.
+		assertCountEqual(t, len(set.SContainsMap("myset", nil)), 0)
+	})
+}
+
+func TestSet_SRandomPair(t *testing.T) {
+	set := New()
+	r := rand.New(rand.NewSource(42))
+
+	t.Run("Pair from Sets with Fewer than Two Members", func(t *testing.T) {
+		// Test sampling a pair from a non-existent set and a single-member set.
+		// It ensures that ok is false and no members are returned.
+		a, b, ok := set.SRandomPair("nonexistent", r)
+		assertKeyDoesNotExist(t, ok)
+		if a != nil || b != nil {
+			t.Errorf("Expected nil members, but got %v and %v", a, b)
+		}
+
+		set.SAdd("single", "a")
+		_, _, ok = set.SRandomPair("single", r)
+		assertKeyDoesNotExist(t, ok)
+	})
+
+	t.Run("Pair from Two-Member Set", func(t *testing.T) {
+		// Test sampling a pair from a set with exactly two members.
+		// It ensures that both members are returned.
+		set.SAdd("pair", "a", "b")
+		a, b, ok := set.SRandomPair("pair", r)
+		assertKeyExists(t, ok)
+		assertSlicesEqualIgnoreOrder(t, []interface{}{a, b}, []interface{}{"a", "b"}, "Pair from Two-Member Set")
+	})
+
+	t.Run("Pairs from Larger Set are Distinct", func(t *testing.T) {
+		// Test sampling many pairs from a larger set.
+		// It ensures that the members of each pair are distinct and the set is not mutated.
+		set.SAdd("players", 1, 2, 3, 4, 5, 6, 7, 8)
+		for i := 0; i < 1000; i++ {
+			a, b, ok := set.SRandomPair("players", r)
+			assertKeyExists(t, ok)
+			if a == b {
+				t.Fatalf("Expected distinct members, but got %v twice", a)
+			}
+			assertKeyExists(t, set.SIsMember("players", a))
+			assertKeyExists(t, set.SIsMember("players", b))
+		}
+		assertSetSize(t, set, "players", 8)
+	})
+
+	t.Run("Seeded Pairs are Reproducible", func(t *testing.T) {
+		// Test drawing pairs from two Sets holding the same members, added in opposite orders, with identically
+		// seeded sources.
+		// It ensures that both draw the same pairs, whatever the map iteration order.
+		set1, set2 := New(), New()
+		for i := 0; i < 50; i++ {
+			set1.SAdd("players", i)
+			set2.SAdd("players", 49-i)
+		}
+
+		r1, r2 := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+		for i := 0; i < 20; i++ {
+			a1, b1, _ := set1.SRandomPair("players", r1)
+			a2, b2, _ := set2.SRandomPair("players", r2)
+			assertSlicesEqual(t, []interface{}{a1, b1}, []interface{}{a2, b2})
+		}
+	})
+
+	t.Run("Nil Source Uses the Set's Source", func(t *testing.T) {
+		// Test drawing pairs without a source from two Sets seeded identically.
+		// It ensures that no panic occurs and that the pairs come from the source of the Set.
+		set1, set2 := New(WithRandSeed(3)), New(WithRandSeed(3))
+		set1.SAdd("players", "a", "b", "c", "d")
+		set2.SAdd("players", "d", "c", "b", "a")
+
+		for i := 0; i < 10; i++ {
+			a1, b1, ok := set1.SRandomPair("players", nil)
+			assertKeyExists(t, ok)
+			a2, b2, _ := set2.SRandomPair("players", nil)
+			assertSlicesEqual(t, []interface{}{a1, b1}, []interface{}{a2, b2})
+		}
+	})
+}
+
+func TestSet_SScanStable(t *testing.T) {
+	set := New()
+
+	t.Run("Scan Full Set", func(t *testing.T) {
+		// Test iterating over a whole set page by page.
+		// It ensures that every member is returned exactly once and the last token is empty.
+		for i := 0; i < 25; i++ {
+			set.SAdd("myset", i)
+		}
+
+		var result []interface{}
+		token, pages := "", 0
+		for {
+			var page []interface{}
+			page, token = set.SScanStable("myset", token, 10)
+			result = append(result, page...)
+			pages++
+			if token == "" {
+				break
+			}
+		}
+
+		assertCountEqual(t, pages, 3)
+		assertSlicesEqualIgnoreOrder(t, result, set.SMembers("myset"), "Scan Full Set")
+	})
+
+	t.Run("Scan While Mutating", func(t *testing.T) {
+		// Test iterating over a set while members are added and removed between pages.
+		// It ensures that removed members are skipped and untouched members are returned exactly once.
+		set.SAdd("mutating", "b", "d", "f", "h")
+
+		page, token := set.SScanStable("mutating", "", 2)
+		assertSlicesEqual(t, page, []interface{}{"b", "d"})
+
+		set.SRem("mutating", "f")
+		set.SAdd("mutating", "a", "g")
+
+		page, token = set.SScanStable("mutating", token, 2)
+		assertSlicesEqual(t, page, []interface{}{"g", "h"})
+		if token != "" {
+			t.Errorf("Expected the iteration to be complete, but got token %q", token)
+		}
+	})
+
+	t.Run("Sort Keys Computed Once", func(t *testing.T) {
+		// Test scanning a page of a set whose members count how many times they are formatted.
+		// It ensures that the sort key of each member is computed once per call, not on every comparison.
+		formatted := 0
+		for i := 0; i < 100; i++ {
+			set.SAdd("counted", countingStringer{id: i, formatted: &formatted})
+		}
+
+		page, _ := set.SScanStable("counted", "", 10)
+		assertCountEqual(t, len(page), 10)
+		assertCountEqual(t, formatted, 100)
+	})
+
+	t.Run("Scan Non-Existent Set", func(t *testing.T) {
+		// Test iterating over a non-existent set.
+		// It ensures that an empty page and an empty token are returned.
+		page, token := set.SScanStable("nonexistent", "", 10)
+		assertEmptySlice(t, page)
+		if token != "" {
+			t.Errorf("Expected an empty token, but got %q", token)
+		}
+	})
+}
+
+func TestSet_SAddReturningSet(t *testing.T) {
+	set := New()
+
+	t.Run("Returned Set Holds Only New Members", func(t *testing.T) {
+		// Test adding a mix of new and pre-existing members.
+		// It ensures that the returned Set holds exactly the newly added members and the main Set holds all of them.
+		set.SAdd("myset", "a", "b")
+		delta := set.SAddReturningSet("myset", "b", "c", "d", "c")
+
+		assertSlicesEqualIgnoreOrder(t, delta.SMembers("myset"), []interface{}{"c", "d"}, "Returned Set Holds Only New Members")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b", "c", "d"}, "Returned Set Holds Only New Members")
+	})
+
+	t.Run("Returned Set is Independent", func(t *testing.T) {
+		// Test mutating the returned Set and the main Set.
+		// It ensures that neither mutation is visible through the other one.
+		delta := set.SAddReturningSet("other", "x", "y")
+		delta.SAdd("other", "z")
+		set.SRem("other", "x")
+
+		assertSlicesEqualIgnoreOrder(t, delta.SMembers("other"), []interface{}{"x", "y", "z"}, "Returned Set is Independent")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("other"), []interface{}{"y"}, "Returned Set is Independent")
+	})
+
+	t.Run("Nothing Newly Added", func(t *testing.T) {
+		// Test adding only members that already exist.
+		// It ensures that the returned Set holds no key, rather than an empty set under the key.
+		delta := set.SAddReturningSet("myset", "a", "b")
+		assertKeyDoesNotExist(t, delta.SKeyExists("myset"))
+		assertCountEqual(t, delta.SKeyCount(), 0)
+		assertSetSize(t, set, "myset", 4)
+	})
+
+	t.Run("Behaves Like SAdd", func(t *testing.T) {
+		// Test adding members that cannot be map keys, and members past the cap of the key.
+		// It ensures that invalid members are skipped without panicking and that the cap is respected.
+		capped := New()
+		capped.SSetMaxCard("capped", 1)
+
+		delta := capped.SAddReturningSet("capped", []int{1}, "a", "b", "c")
+		assertSlicesEqual(t, delta.SMembers("capped"), []interface{}{"a"})
+		assertSetSize(t, capped, "capped", 1)
+	})
+}
+
+func TestSet_SOverlapStore(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c", "d")
+	set.SAdd("set2", "b", "c", "e")
+	set.SAdd("set3", "c", "d", "e", "f")
+
+	t.Run("Intersection-Equivalent Threshold", func(t *testing.T) {
+		// Test storing the members appearing in every set.
+		// It ensures that the stored result matches the intersection.
+		count := set.SOverlapStore("result", 3, "set1", "set2", "set3")
+		assertCountEqual(t, count, 1)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), set.SInter("set1", "set2", "set3"), "Intersection-Equivalent Threshold")
+	})
+
+	t.Run("Intermediate Threshold", func(t *testing.T) {
+		// Test storing the members appearing in at least two of three sets.
+		// It ensures that both the stored contents and the count are correct.
+		count := set.SOverlapStore("result", 2, "set1", "set2", "set3")
+		assertCountEqual(t, count, 4)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"b", "c", "d", "e"}, "Intermediate Threshold")
+	})
+
+	t.Run("Threshold Below One", func(t *testing.T) {
+		// Test storing with a threshold below one.
+		// It ensures that the stored result matches the union.
+		count := set.SOverlapStore("result", 0, "set1", "set2", "set3")
+		assertCountEqual(t, count, 6)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), set.SUnion("set1", "set2", "set3"), "Threshold Below One")
+	})
+
+	t.Run("Empty Result Deletes Destination", func(t *testing.T) {
+		// Test storing with a threshold no member reaches.
+		// It ensures that the destination is deleted.
+		count := set.SOverlapStore("result", 4, "set1", "set2", "set3", "nonexistent")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+}
+
+func TestSet_SInterThreshold(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c", "d")
+	set.SAdd("set2", "b", "c", "e")
+	set.SAdd("set3", "c", "d", "e", "f")
+	set.SAdd("set4", "c", "f", "g")
+
+	keys := []string{"set1", "set2", "set3", "set4"}
+
+	t.Run("Threshold of One Equals Union", func(t *testing.T) {
+		// Test a threshold of one, and one clamped up from below one.
+		// It ensures that the result matches the union.
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(1, keys...), set.SUnion(keys...), "Threshold of One Equals Union")
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(-3, keys...), set.SUnion(keys...), "Threshold of One Equals Union")
+	})
+
+	t.Run("Threshold of N Equals Intersection", func(t *testing.T) {
+		// Test a threshold equal to the number of keys, and one clamped down from above it.
+		// It ensures that the result matches the intersection.
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(4, keys...), set.SInter(keys...), "Threshold of N Equals Intersection")
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(10, keys...), set.SInter(keys...), "Threshold of N Equals Intersection")
+	})
+
+	t.Run("Intermediate Threshold", func(t *testing.T) {
+		// Test thresholds between one and the number of keys.
+		// It ensures that the result holds exactly the members counted by hand.
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(2, keys...), []interface{}{"b", "c", "d", "e", "f"}, "Intermediate Threshold")
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(3, keys...), []interface{}{"c"}, "Intermediate Threshold")
+	})
+
+	t.Run("Non-Existent Keys", func(t *testing.T) {
+		// Test a threshold counting a non-existent key, and no keys at all.
+		// It ensures that the missing key contributes no members.
+		assertSlicesEqualIgnoreOrder(t, set.SInterThreshold(2, "set1", "nonexistent"), set.SInter("set1", "nonexistent"), "Non-Existent Keys")
+		assertEmptySlice(t, set.SInterThreshold(1))
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		// Test storing the result of an intermediate threshold, then a threshold no member reaches.
+		// It ensures that the destination holds the result, and is deleted when it is empty.
+		count := set.SInterThresholdStore("result", 2, keys...)
+		assertCountEqual(t, count, 5)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"b", "c", "d", "e", "f"}, "Store")
+
+		count = set.SInterThresholdStore("result", 2, "set1", "nonexistent")
+		assertCountEqual(t, count, 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+}
+
+func TestSet_Concurrency(t *testing.T) {
+	// These tests are meant to be run with the race detector (go test -race).
+	const goroutines = 16
+	const iterations = 500
+
+	t.Run("Concurrent Access to the Same Key", func(t *testing.T) {
+		// Test adding, removing and listing members of a single key from many goroutines.
+		// It ensures that no data race occurs and the set ends up in a consistent state.
+		set := New()
+		var wg sync.WaitGroup
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					member := fmt.Sprintf("member%d-%d", g, i)
+					set.SAdd("shared", member)
+					set.SMembers("shared")
+					set.SIsMember("shared", member)
+					set.SCard("shared")
+					set.SRem("shared", member)
+				}
+			}(g)
+		}
+
+		wg.Wait()
+		assertSetSize(t, set, "shared", 0)
+	})
+
+	t.Run("Concurrent Access to Different Keys", func(t *testing.T) {
+		// Test adding, removing and combining members of different keys from many goroutines.
+		// It ensures that no data race occurs and every key holds what its goroutine left in it.
+		set := New()
+		var wg sync.WaitGroup
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				key := fmt.Sprintf("key%d", g)
+				for i := 0; i < iterations; i++ {
+					set.SAdd(key, i)
+					set.SMembers(key)
+					set.SUnion(key, "key0")
+					set.SInter(key, "key1")
+					set.SDiff(key, "key2")
+					if i%2 == 0 {
+						set.SRem(key, i)
+					}
+				}
+			}(g)
+		}
+
+		wg.Wait()
+		for g := 0; g < goroutines; g++ {
+			assertSetSize(t, set, fmt.Sprintf("key%d", g), iterations/2)
+		}
+	})
+
+	t.Run("Concurrent Moves are Atomic", func(t *testing.T) {
+		// Test moving the same members between two keys from many goroutines.
+		// It ensures that each member is moved exactly once and no member is lost or duplicated.
+		set := New()
+		for i := 0; i < iterations; i++ {
+			set.SAdd("src", i)
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		moved := 0
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					if set.SMove("src", "dest", i) {
+						mu.Lock()
+						moved++
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+		assertCountEqual(t, moved, iterations)
+		assertSetSize(t, set, "src", 0)
+		assertSetSize(t, set, "dest", iterations)
+	})
+
+	t.Run("Concurrent Compare-And-Swap", func(t *testing.T) {
+		// Test many goroutines racing to swap the same member through a chain of states.
+		// It ensures that exactly one goroutine succeeds for each distinct swap.
+		set := New()
+		set.SAdd("state", 0)
+
+		const swaps = 100
+		var wg sync.WaitGroup
+		successes := make([]int, goroutines)
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < swaps; i++ {
+					if set.SCASMember("state", i, i+1) {
+						successes[g]++
+					}
+				}
+			}(g)
+		}
+
+		wg.Wait()
+
+		total := 0
+		for _, count := range successes {
+			total += count
+		}
+		assertCountEqual(t, total, swaps)
+		assertSlicesEqual(t, set.SMembers("state"), []interface{}{swaps})
+	})
+}
+
+func TestSet_SingleKeyOperations(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Intersection of a Single Set", func(t *testing.T) {
+		// Test the intersection of a single populated set, which lists the set directly.
+		// It ensures that all its members are returned without panicking.
+		result := set.SInter("myset")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Intersection of a Single Set")
+	})
+
+	t.Run("Difference of a Single Set", func(t *testing.T) {
+		// Test the difference of a single populated set, which lists the set directly.
+		// It ensures that all its members are returned without panicking.
+		result := set.SDiff("myset")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Difference of a Single Set")
+	})
+
+	t.Run("Union of a Single Set", func(t *testing.T) {
+		// Test the union of a single populated set.
+		// It ensures that all its members are returned.
+		result := set.SUnion("myset")
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Union of a Single Set")
+	})
+
+	t.Run("Store Operations on a Single Set", func(t *testing.T) {
+		// Test the store variants on a single populated set.
+		// It ensures that the full set is stored each time.
+		assertCountEqual(t, set.SInterStore("interResult", "myset"), 3)
+		assertCountEqual(t, set.SDiffStore("diffResult", "myset"), 3)
+		assertSetSize(t, set, "interResult", 3)
+		assertSetSize(t, set, "diffResult", 3)
+	})
+}
+
+// countingStringer is a member counting how many times it is formatted.
+type countingStringer struct {
+	id        int
+	formatted *int
+}
+
+func (c countingStringer) String() string {
+	*c.formatted++
+	return fmt.Sprint(c.id)
+}
+
+func TestSet_Randomness(t *testing.T) {
+	members := []interface{}{"a", "b", "c", "d", "e"}
+
+	t.Run("Single Pops are Uniform", func(t *testing.T) {
+		// Test popping a single member many times from a set reset before every pop.
+		// It ensures that every member is popped about as often as the others.
+		const trials = 10000
+		set := New(WithRandSeed(1))
+		counts := make(map[interface{}]int)
+
+		for i := 0; i < trials; i++ {
+			set.SClear("myset")
+			set.SAdd("myset", members...)
+			popped := set.SPop("myset", 1)
+			counts[popped[0]]++
+		}
+
+		expected := trials / len(members)
+		for _, member := range members {
+			if counts[member] < expected*85/100 || counts[member] > expected*115/100 {
+				t.Errorf("Expected %v to be popped about %d times, but got %d", member, expected, counts[member])
+			}
+		}
+	})
+
+	t.Run("Single Random Members are Uniform", func(t *testing.T) {
+		// Test retrieving a single random member many times.
+		// It ensures that every member is returned about as often as the others.
+		const trials = 10000
+		set := New(WithRandSeed(2))
+		set.SAdd("myset", members...)
+		counts := make(map[interface{}]int)
+
+		for i := 0; i < trials; i++ {
+			counts[set.SRandMember("myset", 1)[0]]++
+		}
+
+		expected := trials / len(members)
+		for _, member := range members {
+			if counts[member] < expected*85/100 || counts[member] > expected*115/100 {
+				t.Errorf("Expected %v to be returned about %d times, but got %d", member, expected, counts[member])
+			}
+		}
+	})
+
+	t.Run("Unseeded Draws Skip Ordering", func(t *testing.T) {
+		// Test popping and retrieving random members from a Set whose source of randomness was not chosen.
+		// It ensures that no member is formatted to order the set, which would cost a sort of the whole set per draw,
+		// and that single draws stay uniform.
+		const trials = 10000
+		set := New()
+		formatted := 0
+		counts := make(map[interface{}]int)
+		for i := 0; i < 5; i++ {
+			set.SAdd("myset", countingStringer{id: i, formatted: &formatted})
+		}
+
+		for i := 0; i < trials; i++ {
+			counts[set.SRandMember("myset", 1)[0]]++
+		}
+		set.SRandMember("myset", -3)
+		set.SPop("myset", 2)
+
+		assertCountEqual(t, formatted, 0)
+		expected := trials / 5
+		for member, count := range counts {
+			if count < expected*85/100 || count > expected*115/100 {
+				t.Errorf("Expected %v to be returned about %d times, but got %d", member, expected, count)
+			}
+		}
+	})
+
+	t.Run("Seeded Sets are Deterministic", func(t *testing.T) {
+		// Test popping from two Sets seeded identically.
+		// It ensures that both produce the same sequence of popped members.
+		set1 := New(WithRandSeed(42))
+		set2 := New(WithRandSeed(42))
+		set1.SAdd("myset", 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		set2.SAdd("myset", 10, 9, 8, 7, 6, 5, 4, 3, 2, 1)
+
+		for i := 0; i < 5; i++ {
+			assertSlicesEqual(t, set1.SPop("myset", 2), set2.SPop("myset", 2))
+		}
+	})
+
+	t.Run("Injected Sources are Deterministic", func(t *testing.T) {
+		// Test popping from two Sets given identically seeded sources, one as an option and one once created,
+		// and reseeding both midway.
+		// It ensures that both produce the same sequence of popped and random members.
+		set1 := New(WithRandSource(rand.New(rand.NewSource(7))))
+		set2 := New()
+		set2.SetRandSource(rand.New(rand.NewSource(7)))
+		for i := 0; i < 20; i++ {
+			set1.SAdd("myset", i)
+			set2.SAdd("myset", 19-i)
+		}
+
+		for i := 0; i < 3; i++ {
+			assertSlicesEqual(t, set1.SPop("myset", 2), set2.SPop("myset", 2))
+			assertSlicesEqual(t, set1.SRandMember("myset", -3), set2.SRandMember("myset", -3))
+		}
+
+		set1.SetRandSource(rand.New(rand.NewSource(9)))
+		set2.SetRandSource(rand.New(rand.NewSource(9)))
+		for i := 0; i < 3; i++ {
+			assertSlicesEqual(t, set1.SPop("myset", 2), set2.SPop("myset", 2))
+		}
+	})
+
+	t.Run("Nil Source Restores the Default", func(t *testing.T) {
+		// Test passing a nil source as an option and once the Set is in use.
+		// It ensures that randomized operations keep working from an unseeded default source.
+		set1 := New(WithRandSource(nil))
+		set2 := New(WithRandSeed(1))
+		set2.SetRandSource(nil)
+		for _, set := range []*Set{set1, set2} {
+			set.SAdd("myset", members...)
+			assertKeyDoesNotExist(t, set.seeded)
+			assertCountEqual(t, len(set.SRandMember("myset", 3)), 3)
+			assertCountEqual(t, len(set.SPop("myset", 2)), 2)
+		}
+	})
+
+	t.Run("Random Members are Distinct", func(t *testing.T) {
+		// Test retrieving more random members than the set holds.
+		// It ensures that each member is returned exactly once.
+		set := New()
+		set.SAdd("myset", members...)
+		assertSlicesEqualIgnoreOrder(t, set.SRandMember("myset", 10), members, "Random Members are Distinct")
+		assertSetSize(t, set, "myset", len(members))
+	})
+
+	t.Run("Single Distinct Random Members are Uniform", func(t *testing.T) {
+		// Test retrieving a single distinct random member many times.
+		// It ensures that every member is returned about as often as the others.
+		const trials = 10000
+		set := New(WithRandSeed(3))
+		set.SAdd("myset", members...)
+		counts := make(map[interface{}]int)
+
+		for i := 0; i < trials; i++ {
+			counts[set.SRandMemberDistinct("myset", 1)[0]]++
+		}
+
+		expected := trials / len(members)
+		for _, member := range members {
+			if counts[member] < expected*85/100 || counts[member] > expected*115/100 {
+				t.Errorf("Expected %v to be returned about %d times, but got %d", member, expected, counts[member])
+			}
+		}
+	})
+
+	t.Run("Distinct Random Members Beyond Cardinality", func(t *testing.T) {
+		// Test retrieving more distinct random members than the set holds, and non-positive counts.
+		// It ensures that each member is returned exactly once, and that non-positive counts return nothing.
+		set := New()
+		set.SAdd("myset", members...)
+		assertSlicesEqualIgnoreOrder(t, set.SRandMemberDistinct("myset", 10), members, "Distinct Random Members Beyond Cardinality")
+		assertEmptySlice(t, set.SRandMemberDistinct("myset", 0))
+		assertEmptySlice(t, set.SRandMemberDistinct("myset", -3))
+		assertEmptySlice(t, set.SRandMemberDistinct("nonexistent", 3))
+		assertSetSize(t, set, "myset", len(members))
+	})
+
+	t.Run("Sampled Members are Uniform", func(t *testing.T) {
+		// Test sampling two members of a larger set many times.
+		// It ensures that every member is sampled about as often as the others, and never twice in one sample.
+		const trials = 20000
+		set := New(WithRandSeed(5))
+		for i := 0; i < 10; i++ {
+			set.SAdd("myset", i)
+		}
+		counts := make(map[interface{}]int)
+
+		for i := 0; i < trials; i++ {
+			sampled := set.SSample("myset", 2)
+			assertCountEqual(t, len(sampled), 2)
+			if sampled[0] == sampled[1] {
+				t.Fatalf("Expected distinct members, but got %v", sampled)
+			}
+			for _, member := range sampled {
+				counts[member]++
+			}
+		}
+
+		expected := trials * 2 / 10
+		for i := 0; i < 10; i++ {
+			if counts[i] < expected*85/100 || counts[i] > expected*115/100 {
+				t.Errorf("Expected %v to be sampled about %d times, but got %d", i, expected, counts[i])
+			}
+		}
+	})
+
+	t.Run("Sample Beyond Cardinality", func(t *testing.T) {
+		// Test sampling as many or more members than the set holds, and non-positive counts.
+		// It ensures that the whole set is returned, and that non-positive counts return nothing.
+		set := New()
+		set.SAdd("myset", members...)
+		assertSlicesEqualIgnoreOrder(t, set.SSample("myset", len(members)), members, "Sample Beyond Cardinality")
+		assertSlicesEqualIgnoreOrder(t, set.SSample("myset", 10), members, "Sample Beyond Cardinality")
+		assertEmptySlice(t, set.SSample("myset", 0))
+		assertEmptySlice(t, set.SSample("myset", -1))
+		assertEmptySlice(t, set.SSample("nonexistent", 3))
+		assertSetSize(t, set, "myset", len(members))
+	})
+}
+
+func TestSet_SMIsMember(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Mixed Present and Absent Members", func(t *testing.T) {
+		// Test checking a mix of present and absent members.
+		// It ensures that the result is aligned positionally with the input.
+		exists := set.SMIsMember("myset", "a", "x", "c", "y", "a")
+		expected := []bool{true, false, true, false, true}
+		if fmt.Sprint(exists) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, but got %v", expected, exists)
+		}
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test checking members against a non-existent set.
+		// It ensures that an all-false slice of the correct length is returned.
+		exists := set.SMIsMember("nonexistent", "a", "b")
+		expected := []bool{false, false}
+		if fmt.Sprint(exists) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, but got %v", expected, exists)
+		}
+	})
+
+	t.Run("No Members", func(t *testing.T) {
+		// Test checking an empty list of members.
+		// It ensures that an empty slice is returned.
+		assertCountEqual(t, len(set.SMIsMember("myset")), 0)
+	})
+}
+
+func TestSet_SContainsAny(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("First Member Present", func(t *testing.T) {
+		// Test checking candidates whose first one is present, followed by a slice that cannot be looked up.
+		// It ensures that true is returned without looking up the remaining candidates.
+		assertKeyExists(t, set.SContainsAny("myset", "a", []int{1}))
+	})
+
+	t.Run("Later Member Present", func(t *testing.T) {
+		// Test checking candidates of which only the last one is present.
+		// It ensures that true is returned.
+		assertKeyExists(t, set.SContainsAny("myset", "x", "y", "c"))
+	})
+
+	t.Run("No Member Present", func(t *testing.T) {
+		// Test checking candidates none of which is present, and candidates against a non-existent key.
+		// It ensures that false is returned.
+		assertKeyDoesNotExist(t, set.SContainsAny("myset", "x", "y", 1))
+		assertKeyDoesNotExist(t, set.SContainsAny("nonexistent", "a"))
+	})
+
+	t.Run("No Members", func(t *testing.T) {
+		// Test checking an empty list of candidates.
+		// It ensures that false is returned.
+		assertKeyDoesNotExist(t, set.SContainsAny("myset"))
+		assertKeyDoesNotExist(t, set.SContainsAny("myset", []interface{}{}...))
+	})
+}
+
+func TestSet_SInterCard(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c", "d", "e")
+	set.SAdd("set2", "b", "c", "d", "e", "f")
+	set.SAdd("set3", "c", "d", "e", "g")
+
+	t.Run("Count Without Limit", func(t *testing.T) {
+		// Test counting the intersection with no limit.
+		// It ensures that the full intersection is counted.
+		count := set.SInterCard(0, "set1", "set2", "set3")
+		assertCountEqual(t, count, 3)
+		assertCountEqual(t, count, len(set.SInter("set1", "set2", "set3")))
+	})
+
+	t.Run("Count With Limit Smaller than Intersection", func(t *testing.T) {
+		// Test counting the intersection with a limit below its size.
+		// It ensures that counting stops at the limit.
+		assertCountEqual(t, set.SInterCard(2, "set1", "set2", "set3"), 2)
+	})
+
+	t.Run("Count With Limit Larger than Intersection", func(t *testing.T) {
+		// Test counting the intersection with a limit above its size.
+		// It ensures that the full intersection is counted.
+		assertCountEqual(t, set.SInterCard(10, "set1", "set2"), 4)
+	})
+
+	t.Run("Count With Non-Existent Set", func(t *testing.T) {
+		// Test counting an intersection involving a non-existent set.
+		// It ensures that 0 is returned.
+		assertCountEqual(t, set.SInterCard(0, "set1", "nonexistent"), 0)
+		assertCountEqual(t, set.SInterCard(0), 0)
+	})
+}
+
+func TestSet_SUnionCard(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "b", "c", "d")
+	set.SAdd("set3", 1, 2, "a")
+
+	t.Run("Matches Union", func(t *testing.T) {
+		// Test counting unions of various inputs, including repeated, single and non-existent keys.
+		// It ensures that the count always matches the size of the union.
+		inputs := [][]string{
+			{"set1", "set2"},
+			{"set1", "set2", "set3"},
+			{"set1", "set1"},
+			{"set3"},
+			{"set1", "nonexistent"},
+			{"nonexistent"},
+			{},
+		}
+		for _, keys := range inputs {
+			if count, expected := set.SUnionCard(keys...), len(set.SUnion(keys...)); count != expected {
+				t.Errorf("SUnionCard(%v): Expected %d, but got %d", keys, expected, count)
+			}
+		}
+	})
+
+	t.Run("Leaves Sets Unchanged", func(t *testing.T) {
+		// Test counting the union of two sets.
+		// It ensures that the sets are not modified.
+		set.SUnionCard("set1", "set2")
+		assertSetSize(t, set, "set1", 3)
+		assertSetSize(t, set, "set2", 3)
+	})
+}
+
+func TestSet_SUnionCounts(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "b", "c", "d")
+	set.SAdd("set3", "c", "d", "e")
+
+	t.Run("Three Overlapping Sets", func(t *testing.T) {
+		// Test counting the members of three overlapping sets.
+		// It ensures that each member is mapped to the number of sets containing it.
+		counts := set.SUnionCounts("set1", "set2", "set3")
+		expected := map[interface{}]int{"a": 1, "b": 2, "c": 3, "d": 2, "e": 1}
+
+		assertCountEqual(t, len(counts), len(expected))
+		for member, count := range expected {
+			if counts[member] != count {
+				t.Errorf("Member %v: Expected %d, but got %d", member, count, counts[member])
+			}
+		}
+	})
+
+	t.Run("Generalizes Union and Intersection", func(t *testing.T) {
+		// Test the members counted at least once and those counted once per set.
+		// It ensures that they match the union and the intersection of the sets.
+		counts := set.SUnionCounts("set1", "set2", "set3")
+		union, inter := []interface{}{}, []interface{}{}
+		for member, count := range counts {
+			union = append(union, member)
+			if count == 3 {
+				inter = append(inter, member)
+			}
+		}
+
+		assertSlicesEqualIgnoreOrder(t, union, set.SUnion("set1", "set2", "set3"), "Generalizes Union and Intersection")
+		assertSlicesEqualIgnoreOrder(t, inter, set.SInter("set1", "set2", "set3"), "Generalizes Union and Intersection")
+	})
+
+	t.Run("Repeated and Non-Existent Keys", func(t *testing.T) {
+		// Test counting with a repeated key and a non-existent key, and without keys.
+		// It ensures that a repeated key is counted once and that a non-existent key counts nothing.
+		counts := set.SUnionCounts("set1", "set1", "nonexistent")
+		assertCountEqual(t, len(counts), 3)
+		assertCountEqual(t, counts["a"], 1)
+
+		assertCountEqual(t, len(set.SUnionCounts()), 0)
+	})
+}
+
+func TestSet_SDiffCard(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c", "d", "e")
+	set.SAdd("set2", "b", "c")
+	set.SAdd("set3", "e", "f")
+
+	t.Run("Matches Difference", func(t *testing.T) {
+		// Test counting differences of various inputs, including repeated, single and non-existent keys.
+		// It ensures that the count always matches the size of the difference.
+		inputs := [][]string{
+			{"set1", "set2"},
+			{"set1", "set2", "set3"},
+			{"set2", "set1"},
+			{"set1", "set1"},
+			{"set1"},
+			{"set1", "nonexistent"},
+			{"nonexistent", "set1"},
+			{},
+		}
+		for _, keys := range inputs {
+			if count, expected := set.SDiffCard(keys...), len(set.SDiff(keys...)); count != expected {
+				t.Errorf("SDiffCard(%v): Expected %d, but got %d", keys, expected, count)
+			}
+		}
+	})
+
+	t.Run("Count of Known Difference", func(t *testing.T) {
+		// Test counting the difference between a set and two others.
+		// It ensures that the members of either of the others are excluded.
+		assertCountEqual(t, set.SDiffCard("set1", "set2", "set3"), 2)
+	})
+}
+
+func TestSet_SScan(t *testing.T) {
+	set := New()
+	for i := 0; i < 53; i++ {
+		set.SAdd("myset", i)
+	}
+
+	t.Run("Reassemble Full Set", func(t *testing.T) {
+		// Test iterating over a whole set with repeated calls.
+		// It ensures that no member is missed or duplicated and the last cursor is 0.
+		seen := make(map[interface{}]int)
+		cursor, calls := 0, 0
+		for {
+			var page []interface{}
+			cursor, page = set.SScan("myset", cursor, 10)
+			calls++
+			for _, member := range page {
+				seen[member]++
+			}
+			if cursor == 0 {
+				break
+			}
+		}
+
+		assertCountEqual(t, calls, 6)
+		assertCountEqual(t, len(seen), 53)
+		for member, times := range seen {
+			if times != 1 {
+				t.Errorf("Expected %v to be returned once, but got %d times", member, times)
+			}
+		}
+	})
+
+	t.Run("Default Count", func(t *testing.T) {
+		// Test iterating with a count below 1.
+		// It ensures that a page of the default size is returned.
+		cursor, page := set.SScan("myset", 0, 0)
+		assertCountEqual(t, len(page), 10)
+		assertCountEqual(t, cursor, 10)
+	})
+
+	t.Run("Scan Non-Existent Set", func(t *testing.T) {
+		// Test iterating over a non-existent set.
+		// It ensures that an empty page and a cursor of 0 are returned.
+		cursor, page := set.SScan("nonexistent", 0, 10)
+		assertEmptySlice(t, page)
+		assertCountEqual(t, cursor, 0)
+	})
+
+	t.Run("Scan With Out-of-Range Cursor", func(t *testing.T) {
+		// Test iterating with a cursor past the end of the set.
+		// It ensures that an empty page and a cursor of 0 are returned.
+		cursor, page := set.SScan("myset", 100, 10)
+		assertEmptySlice(t, page)
+		assertCountEqual(t, cursor, 0)
+	})
+
+	t.Run("Unbounded Count", func(t *testing.T) {
+		// Test iterating with a count that would overflow when added to the cursor.
+		// It ensures that the rest of the set is returned with a cursor of 0, without panicking.
+		cursor, page := set.SScan("myset", 1, math.MaxInt)
+		assertCountEqual(t, len(page), 52)
+		assertCountEqual(t, cursor, 0)
+
+		cursor, page = set.SScanMatch("myset", 1, math.MaxInt, "*")
+		assertCountEqual(t, len(page), 52)
+		assertCountEqual(t, cursor, 0)
+	})
+
+	t.Run("Cached Order Follows Modifications", func(t *testing.T) {
+		// Test scanning, modifying the returned page and the set, and scanning again.
+		// It ensures that the cached order is neither corrupted by the page nor kept after the set changes.
+		scanned := New()
+		scanned.SAdd("myset", "b", "c")
+
+		_, page := scanned.SScan("myset", 0, 10)
+		page[0] = "z"
+		_, page = scanned.SScan("myset", 0, 10)
+		assertSlicesEqual(t, page, []interface{}{"b", "c"})
+
+		scanned.SAdd("myset", "a")
+		scanned.SRem("myset", "c")
+		_, page = scanned.SScan("myset", 0, 10)
+		assertSlicesEqual(t, page, []interface{}{"a", "b"})
+	})
+}
+
+func TestSet_SScanMatch(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "user:1", "user:2", "user:10", "session:1", "session:2", "admin", 42, 420)
+
+	scanAll := func(pattern string, count int) []interface{} {
+		var result []interface{}
+		cursor := 0
+		for {
+			var page []interface{}
+			cursor, page = set.SScanMatch("myset", cursor, count, pattern)
+			result = append(result, page...)
+			if cursor == 0 {
+				return result
+			}
+		}
+	}
+
+	t.Run("Match With Star", func(t *testing.T) {
+		// Test scanning with a pattern using *.
+		// It ensures that only the members with the prefix are returned.
+		result := scanAll("user:*", 3)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"user:1", "user:2", "user:10"}, "Match With Star")
+	})
+
+	t.Run("Match With Question Mark", func(t *testing.T) {
+		// Test scanning with a pattern using ?.
+		// It ensures that exactly one character is matched.
+		result := scanAll("user:?", 3)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"user:1", "user:2"}, "Match With Question Mark")
+	})
+
+	t.Run("Match With Character Class", func(t *testing.T) {
+		// Test scanning with a pattern using a character class.
+		// It ensures that only the listed characters are matched.
+		result := scanAll("[su]*:2", 3)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"user:2", "session:2"}, "Match With Character Class")
+	})
+
+	t.Run("Match Non-String Members", func(t *testing.T) {
+		// Test scanning with a pattern matching the string form of non-string members.
+		// It ensures that numbers are matched against their fmt.Sprint representation.
+		result := scanAll("42*", 3)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{42, 420}, "Match Non-String Members")
+	})
+
+	t.Run("Match Nothing Still Advances Cursor", func(t *testing.T) {
+		// Test scanning with a pattern that matches nothing.
+		// It ensures that an empty page is returned while the cursor still advances.
+		cursor, page := set.SScanMatch("myset", 0, 3, "nothing*")
+		assertEmptySlice(t, page)
+		assertCountEqual(t, cursor, 3)
+		assertEmptySlice(t, scanAll("nothing*", 3))
+	})
+
+	t.Run("Count Bounds Each Call", func(t *testing.T) {
+		// Test scanning with a count of 1, with a pattern matching everything and a pattern matching little.
+		// It ensures that each call returns at most one member and advances by exactly one, so that a full scan
+		// takes as many calls as the set has members, without skipping any member.
+		for _, pattern := range []string{"*", "user:*"} {
+			var result []interface{}
+			cursor, calls := 0, 0
+			for {
+				var page []interface{}
+				cursor, page = set.SScanMatch("myset", cursor, 1, pattern)
+				calls++
+				if len(page) > 1 {
+					t.Fatalf("Expected at most 1 member per call, but got %v", page)
+				}
+				result = append(result, page...)
+				if cursor == 0 {
+					break
+				}
+				assertCountEqual(t, cursor, calls)
+			}
+
+			assertCountEqual(t, calls, set.SCard("myset"))
+			assertSlicesEqualIgnoreOrder(t, result, scanAll(pattern, 100), "Count Bounds Each Call")
+		}
+	})
+}
+
+func TestSet_EmptyKeysAreDeleted(t *testing.T) {
+	set := New()
+
+	t.Run("Remove Last Member", func(t *testing.T) {
+		// Test removing the last member of a set.
+		// It ensures that the key no longer exists.
+		set.SAdd("myset", "a", "b")
+		set.SRem("myset", "a", "b")
+		assertKeyDoesNotExist(t, set.SKeyExists("myset"))
+		assertSetSize(t, set, "myset", 0)
+	})
+
+	t.Run("Pop Last Member", func(t *testing.T) {
+		// Test popping every member of a set.
+		// It ensures that the key no longer exists.
+		set.SAdd("popped", "a", "b", "c")
+		set.SPop("popped", 2)
+		assertKeyExists(t, set.SKeyExists("popped"))
+		set.SPop("popped", 1)
+		assertKeyDoesNotExist(t, set.SKeyExists("popped"))
+	})
+
+	t.Run("Move Last Member", func(t *testing.T) {
+		// Test moving the last member of a set to another one.
+		// It ensures that the source key no longer exists while the destination does.
+		set.SAdd("src", "a")
+		set.SMove("src", "dest", "a")
+		assertKeyDoesNotExist(t, set.SKeyExists("src"))
+		assertKeyExists(t, set.SKeyExists("dest"))
+	})
+
+	t.Run("Move Last Member to the Same Key", func(t *testing.T) {
+		// Test moving the last member of a set onto the same key.
+		// It ensures that the key and its member are kept.
+		set.SAdd("self", "a")
+		set.SMove("self", "self", "a")
+		assertKeyExists(t, set.SIsMember("self", "a"))
+	})
+
+	t.Run("Add Nothing", func(t *testing.T) {
+		// Test adding no members, or only members that cannot be added, through every adding operation.
+		// It ensures that no empty key is left behind.
+		set.SAdd("nothing")
+		set.SAdd("nothing", []int{1}, map[string]int{})
+		set.SAddSlice("nothing", []interface{}{[]int{1}})
+		set.SAddChecked("nothing", []int{1})
+		set.SAddBounded("nothing", []int{1})
+		set.MultiSAdd(map[string][]interface{}{"nothing": {[]int{1}}})
+		set.SAddReturningSet("nothing", []int{1})
+
+		assertKeyDoesNotExist(t, set.SKeyExists("nothing"))
+		assertSetSize(t, set, "nothing", 0)
+	})
+
+	t.Run("Re-Add After Deletion", func(t *testing.T) {
+		// Test adding members to a key deleted after its last member was removed.
+		// It ensures that the key is re-created cleanly.
+		set.SAdd("myset", "c")
+		assertKeyExists(t, set.SKeyExists("myset"))
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"c"})
+	})
+}
+
+func TestSet_SKeys(t *testing.T) {
+	t.Run("Keys of an Empty Set", func(t *testing.T) {
+		// Test listing the keys of a Set holding no keys.
+		// It ensures that an empty, non-nil slice is returned.
+		keys := New().SKeys()
+		if keys == nil || len(keys) != 0 {
+			t.Errorf("Expected an empty slice, but got %v", keys)
+		}
+	})
+
+	t.Run("Keys of a Populated Set", func(t *testing.T) {
+		// Test listing the keys of a Set holding several keys.
+		// It ensures that every key is returned exactly once.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", "c")
+		set.SAdd("set3", "d")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"set1", "set2", "set3"}, "Keys of a Populated Set")
+	})
+
+	t.Run("Keys Matching a Pattern", func(t *testing.T) {
+		// Test listing the keys matching glob patterns.
+		// It ensures that only matching keys are returned and that * matches every key.
+		set := New()
+		set.SAdd("user:1", "a")
+		set.SAdd("user:2", "b")
+		set.SAdd("order:1", "c")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeysMatch("user:*")), []interface{}{"user:1", "user:2"}, "Keys Matching a Pattern")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeysMatch("*:1")), []interface{}{"user:1", "order:1"}, "Keys Matching a Pattern")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeysMatch("*")), []interface{}{"user:1", "user:2", "order:1"}, "Keys Matching a Pattern")
+		assertCountEqual(t, len(set.SKeysMatch("product:*")), 0)
+	})
+}
+
+func TestSet_SClearMatch(t *testing.T) {
+	populated := func() *Set {
+		set := New()
+		set.SAdd("session:1", "a")
+		set.SAdd("session:2", "b")
+		set.SAdd("user:1", "c")
+		return set
+	}
+
+	t.Run("Pattern Matching Some Keys", func(t *testing.T) {
+		// Test clearing the keys matching a prefix pattern.
+		// It ensures that only the matching keys are deleted and counted.
+		set := populated()
+		assertCountEqual(t, set.SClearMatch("session:*"), 2)
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"user:1"}, "Pattern Matching Some Keys")
+	})
+
+	t.Run("Pattern Matching All Keys", func(t *testing.T) {
+		// Test clearing with a pattern matching every key.
+		// It ensures that the Set is left empty.
+		set := populated()
+		assertCountEqual(t, set.SClearMatch("*"), 3)
+		assertCountEqual(t, len(set.SKeys()), 0)
+	})
+
+	t.Run("Pattern Matching No Keys", func(t *testing.T) {
+		// Test clearing with a pattern matching no key, and clearing an empty Set.
+		// It ensures that nothing is deleted.
+		set := populated()
+		assertCountEqual(t, set.SClearMatch("order:*"), 0)
+		assertCountEqual(t, len(set.SKeys()), 3)
+		assertCountEqual(t, New().SClearMatch("*"), 0)
+	})
+}
+
+func TestSet_SFlush(t *testing.T) {
+	t.Run("Flush Populated Set", func(t *testing.T) {
+		// Test flushing a Set holding several keys.
+		// It ensures that no key remains and that former keys report no members.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", "c")
+		set.SAdd("set3", "d", "e", "f")
+
+		set.SFlush()
+		assertCountEqual(t, len(set.SKeys()), 0)
+		assertSetSize(t, set, "set1", 0)
+		assertSetSize(t, set, "set3", 0)
+
+		set.SAdd("set1", "g")
+		assertSlicesEqual(t, set.SMembers("set1"), []interface{}{"g"})
+	})
+
+	t.Run("Flush Count", func(t *testing.T) {
+		// Test flushing a Set while counting the removed keys.
+		// It ensures that the number of removed keys is returned, and 0 once the Set is empty.
+		set := New()
+		set.SAdd("set1", "a")
+		set.SAdd("set2", "b")
+
+		assertCountEqual(t, set.SFlushCount(), 2)
+		assertCountEqual(t, len(set.SKeys()), 0)
+		assertCountEqual(t, set.SFlushCount(), 0)
+	})
+}
+
+func TestSet_SClone(t *testing.T) {
+	t.Run("Clone Is Independent", func(t *testing.T) {
+		// Test cloning a Set and mutating both the clone and the original.
+		// It ensures that membership and cardinality changes on one side are not visible on the other.
+		set := New()
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "d")
+
+		clone := set.SClone()
+		assertSlicesEqualIgnoreOrder(t, clone.SMembers("set1"), []interface{}{"a", "b", "c"}, "Clone Is Independent")
+		assertSlicesEqualIgnoreOrder(t, clone.SMembers("set2"), []interface{}{"d"}, "Clone Is Independent")
+
+		set.SAdd("set1", "x")
+		set.SRem("set2", "d")
+		clone.SRem("set1", "a")
+		clone.SAdd("set3", "y")
+
+		assertSetSize(t, set, "set1", 4)
+		assertKeyExists(t, set.SIsMember("set1", "x"))
+		assertKeyDoesNotExist(t, set.SKeyExists("set2"))
+		assertKeyDoesNotExist(t, set.SKeyExists("set3"))
+
+		assertSlicesEqualIgnoreOrder(t, clone.SMembers("set1"), []interface{}{"b", "c"}, "Clone Is Independent")
+		assertSetSize(t, clone, "set2", 1)
+		assertSetSize(t, clone, "set3", 1)
+	})
+
+	t.Run("Clone Empty Set", func(t *testing.T) {
+		// Test cloning a Set holding no keys.
+		// It ensures that the clone is empty and usable.
+		clone := New().SClone()
+		assertCountEqual(t, len(clone.SKeys()), 0)
+		clone.SAdd("myset", "a")
+		assertSetSize(t, clone, "myset", 1)
+	})
+}
+
+func TestSet_SMergeFrom(t *testing.T) {
+	t.Run("Merge Disjoint Keys", func(t *testing.T) {
+		// Test merging a Set whose keys are all absent from the receiver.
+		// It ensures that every key of the other Set is created with its members.
+		set := New()
+		set.SAdd("set1", "a")
+		other := New()
+		other.SAdd("set2", "b", "c")
+
+		set.SMergeFrom(other)
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"set1", "set2"}, "Merge Disjoint Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set2"), []interface{}{"b", "c"}, "Merge Disjoint Keys")
+	})
+
+	t.Run("Merge Overlapping Keys", func(t *testing.T) {
+		// Test merging a Set sharing a key whose members partially overlap with the receiver.
+		// It ensures that the members are unioned and that the other Set is left unchanged and independent.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		other := New()
+		other.SAdd("set1", "b", "c")
+
+		set.SMergeFrom(other)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"a", "b", "c"}, "Merge Overlapping Keys")
+		assertSlicesEqualIgnoreOrder(t, other.SMembers("set1"), []interface{}{"b", "c"}, "Merge Overlapping Keys")
+
+		other.SAdd("set1", "d")
+		assertSetSize(t, set, "set1", 3)
+	})
+
+	t.Run("Merge from Empty Set", func(t *testing.T) {
+		// Test merging a Set holding no keys.
+		// It ensures that the receiver is left unchanged.
+		set := New()
+		set.SAdd("set1", "a")
+
+		set.SMergeFrom(New())
+		set.SMergeFromReplace(New())
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"set1"}, "Merge from Empty Set")
+		assertSlicesEqual(t, set.SMembers("set1"), []interface{}{"a"})
+	})
+
+	t.Run("Merge and Replace Overlapping Keys", func(t *testing.T) {
+		// Test merging a Set sharing a key with the receiver, replacing colliding keys.
+		// It ensures that colliding keys hold only the members of the other Set while other keys are kept.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("kept", "k")
+		other := New()
+		other.SAdd("set1", "b", "c")
+		other.SAdd("set2", "d")
+
+		set.SMergeFromReplace(other)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"b", "c"}, "Merge and Replace Overlapping Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set2"), []interface{}{"d"}, "Merge and Replace Overlapping Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("kept"), []interface{}{"k"}, "Merge and Replace Overlapping Keys")
+	})
+
+	t.Run("Merge Sets from Each Other Concurrently", func(t *testing.T) {
+		// Test two Sets merging from each other at the same time.
+		// It ensures that the merges complete without deadlocking.
+		a := New()
+		a.SAdd("set1", "a")
+		b := New()
+		b.SAdd("set1", "b")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				a.SMergeFrom(b)
+			}()
+			go func() {
+				defer wg.Done()
+				b.SMergeFrom(a)
+			}()
+		}
+		wg.Wait()
+
+		assertSetSize(t, a, "set1", 2)
+		assertSetSize(t, b, "set1", 2)
+	})
+}
+
+func TestSet_SExport(t *testing.T) {
+	t.Run("Export Every Key", func(t *testing.T) {
+		// Test exporting a Set holding several keys, one of them expired.
+		// It ensures that every live key is exported with its members, and that the expired key is left out.
+		now := time.Now()
+		set := New(WithClock(func() time.Time { return now }))
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", 1)
+		set.SAdd("expired", "x")
+		set.SExpire("expired", time.Second)
+		now = now.Add(2 * time.Second)
+
+		data := set.SExport()
+		assertCountEqual(t, len(data), 2)
+		assertSlicesEqualIgnoreOrder(t, data["set1"], []interface{}{"a", "b"}, "Export Every Key")
+		assertSlicesEqualIgnoreOrder(t, data["set2"], []interface{}{1}, "Export Every Key")
+	})
+
+	t.Run("Export Is Independent", func(t *testing.T) {
+		// Test mutating an exported map and the Set it was exported from.
+		// It ensures that neither change is visible through the other.
+		set := New()
+		set.SAdd("set1", "a", "b")
+
+		data := set.SExport()
+		data["set1"][0] = "changed"
+		data["set1"] = append(data["set1"], "c")
+		data["set2"] = []interface{}{"d"}
+		set.SAdd("set1", "e")
+
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"a", "b", "e"}, "Export Is Independent")
+		assertKeyDoesNotExist(t, set.SKeyExists("set2"))
+		assertCountEqual(t, len(data["set1"]), 3)
+	})
+
+	t.Run("Export Empty Set", func(t *testing.T) {
+		// Test exporting a Set without keys.
+		// It ensures that an empty, non-nil map is returned.
+		data := New().SExport()
+		if data == nil || len(data) != 0 {
+			t.Errorf("Expected an empty map, but got %v", data)
+		}
+	})
+}
+
+func TestSet_SImport(t *testing.T) {
+	t.Run("Import Merges", func(t *testing.T) {
+		// Test importing a map sharing a key with the Set without replacing it.
+		// It ensures that the members are unioned, that new keys are created, and that other keys are kept.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("kept", "k")
+
+		set.SImport(map[string][]interface{}{"set1": {"b", "c"}, "set2": {"d"}}, false)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"a", "b", "c"}, "Import Merges")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set2"), []interface{}{"d"}, "Import Merges")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("kept"), []interface{}{"k"}, "Import Merges")
+	})
+
+	t.Run("Import Replaces", func(t *testing.T) {
+		// Test importing a map while replacing the contents of the Set.
+		// It ensures that the Set holds exactly the imported keys and members.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("dropped", "x")
+
+		set.SImport(map[string][]interface{}{"set1": {"c"}, "set2": {"d"}}, true)
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"set1", "set2"}, "Import Replaces")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"c"}, "Import Replaces")
+	})
+
+	t.Run("Skip Empty Keys and Invalid Members", func(t *testing.T) {
+		// Test importing a map holding a key without members and a member that cannot be a map key.
+		// It ensures that neither is imported and that the valid members still are.
+		set := New()
+
+		set.SImport(map[string][]interface{}{"empty": {}, "invalid": {[]int{1}}, "set1": {"a", []int{2}}}, false)
+		assertKeyDoesNotExist(t, set.SKeyExists("empty"))
+		assertKeyDoesNotExist(t, set.SKeyExists("invalid"))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"a"}, "Skip Empty Keys and Invalid Members")
+	})
+
+	t.Run("Round Trip", func(t *testing.T) {
+		// Test importing an exported map into a new Set, then mutating the map.
+		// It ensures that the new Set holds the same sets and keeps no reference to the map.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", 1, 2)
+
+		data := set.SExport()
+		clone := New()
+		clone.SImport(data, true)
+		data["set1"][0] = "changed"
+
+		assertSlicesEqualIgnoreOrder(t, clone.SMembers("set1"), []interface{}{"a", "b"}, "Round Trip")
+		assertSlicesEqualIgnoreOrder(t, clone.SMembers("set2"), []interface{}{1, 2}, "Round Trip")
+	})
+}
+
+func TestSet_SIsSubset(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b")
+	set.SAdd("set2", "a", "b")
+	set.SAdd("superset", "a", "b", "c")
+	set.SAdd("disjoint", "x", "y")
+
+	t.Run("Subset of Equal Sets", func(t *testing.T) {
+		// Test the subset and superset checks between two sets holding the same members.
+		// It ensures that each set is both a subset and a superset of the other.
+		assertKeyExists(t, set.SIsSubset("set1", "set2"))
+		assertKeyExists(t, set.SIsSubset("set2", "set1"))
+		assertKeyExists(t, set.SIsSuperset("set1", "set2"))
+	})
+
+	t.Run("Proper Subset", func(t *testing.T) {
+		// Test the subset and superset checks between a set and a strictly larger set containing it.
+		// It ensures that the relation only holds in one direction.
+		assertKeyExists(t, set.SIsSubset("set1", "superset"))
+		assertKeyDoesNotExist(t, set.SIsSubset("superset", "set1"))
+		assertKeyExists(t, set.SIsSuperset("superset", "set1"))
+		assertKeyDoesNotExist(t, set.SIsSuperset("set1", "superset"))
+	})
+
+	t.Run("Disjoint Sets", func(t *testing.T) {
+		// Test the subset and superset checks between two sets with no common member.
+		// It ensures that neither set is a subset of the other.
+		assertKeyDoesNotExist(t, set.SIsSubset("set1", "disjoint"))
+		assertKeyDoesNotExist(t, set.SIsSubset("disjoint", "set1"))
+		assertKeyDoesNotExist(t, set.SIsSuperset("set1", "disjoint"))
+	})
+
+	t.Run("Empty and Non-Existent Sets", func(t *testing.T) {
+		// Test the subset and superset checks involving keys that don't exist.
+		// It ensures that a missing key behaves as the empty set, which is a subset of any set.
+		assertKeyExists(t, set.SIsSubset("nonexistent", "set1"))
+		assertKeyExists(t, set.SIsSubset("nonexistent1", "nonexistent2"))
+		assertKeyDoesNotExist(t, set.SIsSubset("set1", "nonexistent"))
+		assertKeyExists(t, set.SIsSuperset("set1", "nonexistent"))
+		assertKeyDoesNotExist(t, set.SIsSuperset("nonexistent", "set1"))
+	})
+}
+
+func TestSet_SEquals(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "c", "b", "a")
+	set.SAdd("differing", "a", "b", "d")
+	set.SAdd("smaller", "a", "b")
+
+	t.Run("Identical Sets", func(t *testing.T) {
+		// Test comparing two sets holding the same members added in a different order.
+		// It ensures that they are equal in both directions.
+		assertKeyExists(t, set.SEquals("set1", "set2"))
+		assertKeyExists(t, set.SEquals("set2", "set1"))
+		assertKeyExists(t, set.SEquals("set1", "set1"))
+	})
+
+	t.Run("Same Size with Differing Members", func(t *testing.T) {
+		// Test comparing two sets of the same size that differ by one member.
+		// It ensures that they are not equal.
+		assertKeyDoesNotExist(t, set.SEquals("set1", "differing"))
+	})
+
+	t.Run("Different Sizes", func(t *testing.T) {
+		// Test comparing a set with a strict subset of it.
+		// It ensures that they are not equal in either direction.
+		assertKeyDoesNotExist(t, set.SEquals("set1", "smaller"))
+		assertKeyDoesNotExist(t, set.SEquals("smaller", "set1"))
+	})
+
+	t.Run("Non-Existent Sets", func(t *testing.T) {
+		// Test comparing keys that don't exist with each other and with other sets.
+		// It ensures that missing keys are equal to each other and to empty sets only.
+		set.SAdd("empty")
+		assertKeyExists(t, set.SEquals("nonexistent1", "nonexistent2"))
+		assertKeyExists(t, set.SEquals("nonexistent", "empty"))
+		assertKeyDoesNotExist(t, set.SEquals("nonexistent", "set1"))
+		assertKeyDoesNotExist(t, set.SEquals("set1", "nonexistent"))
+	})
+}
+
+func TestSet_SJaccard(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "c", "b", "a")
+	set.SAdd("disjoint", "x", "y")
+	set.SAdd("partial", "b", "c", "d", "e")
+
+	assertJaccard := func(t *testing.T, keyA, keyB string, expected float64) {
+		t.Helper()
+
+		if actual := set.SJaccard(keyA, keyB); math.Abs(actual-expected) > 1e-9 {
+			t.Errorf("Expected a Jaccard index of %v between %s and %s, but got %v", expected, keyA, keyB, actual)
+		}
+	}
+
+	t.Run("Identical Sets", func(t *testing.T) {
+		// Test the Jaccard index of two sets holding the same members.
+		// It ensures that the index is 1.
+		assertJaccard(t, "set1", "set2", 1)
+	})
+
+	t.Run("Disjoint Sets", func(t *testing.T) {
+		// Test the Jaccard index of two sets with no common member.
+		// It ensures that the index is 0.
+		assertJaccard(t, "set1", "disjoint", 0)
+	})
+
+	t.Run("Partial Overlap", func(t *testing.T) {
+		// Test the Jaccard index of two partially overlapping sets.
+		// It ensures that the index is the size of the intersection {b, c} over the size of the union {a, b, c, d, e}.
+		assertJaccard(t, "set1", "partial", 2.0/5.0)
+		assertJaccard(t, "partial", "set1", 2.0/5.0)
+	})
+
+	t.Run("Empty and Non-Existent Sets", func(t *testing.T) {
+		// Test the Jaccard index involving empty sets and keys that don't exist.
+		// It ensures that two empty sets have an index of 1, and an empty and a non-empty set an index of 0.
+		set.SAdd("empty")
+		assertJaccard(t, "nonexistent1", "nonexistent2", 1)
+		assertJaccard(t, "empty", "nonexistent", 1)
+		assertJaccard(t, "set1", "nonexistent", 0)
+		assertJaccard(t, "nonexistent", "set1", 0)
+	})
+}
+
+func TestSet_SOverlapAndSDice(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "c", "b", "a")
+	set.SAdd("disjoint", "x", "y")
+	set.SAdd("partial", "b", "c", "d", "e")
+	set.SAdd("subset", "a", "b")
+
+	assertCoefficient := func(t *testing.T, name string, actual, expected float64) {
+		t.Helper()
+
+		if math.Abs(actual-expected) > 1e-9 {
+			t.Errorf("Expected a %s coefficient of %v, but got %v", name, expected, actual)
+		}
+	}
+
+	t.Run("Identical Sets", func(t *testing.T) {
+		// Test the coefficients of two sets holding the same members.
+		// It ensures that both coefficients are 1.
+		assertCoefficient(t, "overlap", set.SOverlap("set1", "set2"), 1)
+		assertCoefficient(t, "Dice", set.SDice("set1", "set2"), 1)
+	})
+
+	t.Run("Disjoint Sets", func(t *testing.T) {
+		// Test the coefficients of two sets with no common member.
+		// It ensures that both coefficients are 0.
+		assertCoefficient(t, "overlap", set.SOverlap("set1", "disjoint"), 0)
+		assertCoefficient(t, "Dice", set.SDice("set1", "disjoint"), 0)
+	})
+
+	t.Run("Partial Overlap", func(t *testing.T) {
+		// Test the coefficients of {a, b, c} and {b, c, d, e}, which share {b, c}, in both orders.
+		// It ensures that the overlap coefficient is 2/3 and the Dice coefficient 2*2/(3+4).
+		assertCoefficient(t, "overlap", set.SOverlap("set1", "partial"), 2.0/3.0)
+		assertCoefficient(t, "overlap", set.SOverlap("partial", "set1"), 2.0/3.0)
+		assertCoefficient(t, "Dice", set.SDice("set1", "partial"), 4.0/7.0)
+		assertCoefficient(t, "Dice", set.SDice("partial", "set1"), 4.0/7.0)
+	})
+
+	t.Run("Subset", func(t *testing.T) {
+		// Test the coefficients of a set and one of its proper subsets.
+		// It ensures that the overlap coefficient is 1 while the Dice coefficient is 2*2/(3+2).
+		assertCoefficient(t, "overlap", set.SOverlap("set1", "subset"), 1)
+		assertCoefficient(t, "Dice", set.SDice("set1", "subset"), 4.0/5.0)
+	})
+
+	t.Run("Empty and Non-Existent Sets", func(t *testing.T) {
+		// Test the coefficients involving empty sets and keys that don't exist.
+		// It ensures that two empty sets have coefficients of 1, and an empty and a non-empty set coefficients of 0.
+		set.SAdd("empty")
+		assertCoefficient(t, "overlap", set.SOverlap("nonexistent1", "nonexistent2"), 1)
+		assertCoefficient(t, "overlap", set.SOverlap("empty", "nonexistent"), 1)
+		assertCoefficient(t, "overlap", set.SOverlap("set1", "nonexistent"), 0)
+		assertCoefficient(t, "overlap", set.SOverlap("nonexistent", "set1"), 0)
+		assertCoefficient(t, "Dice", set.SDice("nonexistent1", "nonexistent2"), 1)
+		assertCoefficient(t, "Dice", set.SDice("set1", "nonexistent"), 0)
+		assertCoefficient(t, "Dice", set.SDice("nonexistent", "set1"), 0)
+	})
+}
+
+func TestSet_SFingerprint(t *testing.T) {
+	t.Run("Fingerprint Is Order Independent", func(t *testing.T) {
+		// Test fingerprinting two sets holding the same members added in different orders.
+		// It ensures that their fingerprints are equal.
+		set := New()
+		set.SAdd("set1", "a", "b", "c", 1, 2.5)
+		set.SAdd("set2", 2.5, "c", 1, "a")
+		set.SAdd("set2", "b")
+
+		assertKeyExists(t, set.SFingerprint("set1") == set.SFingerprint("set2"))
+	})
+
+	t.Run("Fingerprint Changes with Membership", func(t *testing.T) {
+		// Test fingerprinting a set before and after adding and removing members.
+		// It ensures that every change of membership changes the fingerprint, and that undoing it restores it.
+		set := New()
+		set.SAdd("myset", "a", "b")
+		initial := set.SFingerprint("myset")
+
+		set.SAdd("myset", "c")
+		added := set.SFingerprint("myset")
+		assertKeyDoesNotExist(t, added == initial)
+
+		set.SRem("myset", "a")
+		removed := set.SFingerprint("myset")
+		assertKeyDoesNotExist(t, removed == added)
+		assertKeyDoesNotExist(t, removed == initial)
+
+		set.SRem("myset", "c")
+		set.SAdd("myset", "a")
+		assertKeyExists(t, set.SFingerprint("myset") == initial)
+	})
+
+	t.Run("Fingerprint Distinguishes Member Types", func(t *testing.T) {
+		// Test fingerprinting sets whose members only differ by their type.
+		// It ensures that their fingerprints differ.
+		set := New()
+		set.SAdd("ints", 1)
+		set.SAdd("strings", "1")
+		assertKeyDoesNotExist(t, set.SFingerprint("ints") == set.SFingerprint("strings"))
+	})
+
+	t.Run("Fingerprint of Non-Existent Set", func(t *testing.T) {
+		// Test fingerprinting a set that doesn't exist.
+		// It ensures that 0 is returned.
+		assertCountEqual(t, int(New().SFingerprint("nonexistent")), 0)
+	})
+}
+
+func TestSet_SMembersSorted(t *testing.T) {
+	set := New()
+	set.SAdd("numbers", 5, 3, 9, 1, 7)
+	set.SAdd("words", "pear", "apple", "fig", 10, 2)
+
+	t.Run("Sorted with a Comparator", func(t *testing.T) {
+		// Test sorting the members of a set with a custom comparator, repeatedly.
+		// It ensures that every call returns the members in the same order defined by the comparator.
+		descending := func(a, b interface{}) bool { return a.(int) > b.(int) }
+		for i := 0; i < 10; i++ {
+			assertSlicesEqual(t, set.SMembersSorted("numbers", descending), []interface{}{9, 7, 5, 3, 1})
+		}
+	})
+
+	t.Run("Sorted Strings", func(t *testing.T) {
+		// Test sorting the string form of the members of a set, repeatedly.
+		// It ensures that every call returns the same lexicographic order, including for non-string members.
+		expected := []string{"10", "2", "apple", "fig", "pear"}
+		for i := 0; i < 10; i++ {
+			actual := set.SMembersSortedStrings("words")
+			if fmt.Sprint(actual) != fmt.Sprint(expected) {
+				t.Fatalf("Expected %v, but got %v", expected, actual)
+			}
+		}
+	})
+
+	t.Run("Sorted Non-Existent Set", func(t *testing.T) {
+		// Test sorting the members of a set that doesn't exist.
+		// It ensures that empty slices are returned and the comparator is never called.
+		members := set.SMembersSorted("nonexistent", func(a, b interface{}) bool {
+			t.Errorf("Expected the comparator not to be called")
+			return false
+		})
+		assertEmptySlice(t, members)
+		assertCountEqual(t, len(set.SMembersSortedStrings("nonexistent")), 0)
+	})
+}
+
+func TestSet_SFilter(t *testing.T) {
+	set := New()
+	set.SAdd("numbers", 1, 5, 10, 15, 20)
+	set.SAdd("words", "user:1", "user:2", "order:1")
+
+	inRange := func(item interface{}) bool {
+		n := item.(int)
+		return n >= 5 && n <= 15
+	}
+	hasUserPrefix := func(item interface{}) bool {
+		return strings.HasPrefix(item.(string), "user:")
+	}
+
+	t.Run("Filter Numbers by Range", func(t *testing.T) {
+		// Test filtering numeric members with a range predicate.
+		// It ensures that only the members within the range are returned and counted.
+		assertSlicesEqualIgnoreOrder(t, set.SFilter("numbers", inRange), []interface{}{5, 10, 15}, "Filter Numbers by Range")
+		assertCountEqual(t, set.SCountFilter("numbers", inRange), 3)
+	})
+
+	t.Run("Filter Strings by Prefix", func(t *testing.T) {
+		// Test filtering string members with a prefix predicate.
+		// It ensures that only the members with the prefix are returned and counted.
+		assertSlicesEqualIgnoreOrder(t, set.SFilter("words", hasUserPrefix), []interface{}{"user:1", "user:2"}, "Filter Strings by Prefix")
+		assertCountEqual(t, set.SCountFilter("words", hasUserPrefix), 2)
+	})
+
+	t.Run("Filter Non-Existent Set", func(t *testing.T) {
+		// Test filtering a set that doesn't exist.
+		// It ensures that nothing is returned and the predicate is never called.
+		never := func(item interface{}) bool {
+			t.Errorf("Expected the predicate not to be called, but got %v", item)
+			return true
+		}
+		assertEmptySlice(t, set.SFilter("nonexistent", never))
+		assertCountEqual(t, set.SCountFilter("nonexistent", never), 0)
+	})
+}
+
+func TestSet_SMaxAndSMin(t *testing.T) {
+	byInt := func(a, b interface{}) bool { return a.(int) < b.(int) }
+
+	t.Run("Numeric Set", func(t *testing.T) {
+		// Test the greatest and least members of a set of integers, including negative ones.
+		// It ensures that both extremes are found, and that a reversed ordering swaps them.
+		set := New()
+		set.SAdd("numbers", 4, -7, 12, 0, 9, 3)
+
+		greatest, ok := set.SMax("numbers", byInt)
+		assertKeyExists(t, ok)
+		assertCountEqual(t, greatest.(int), 12)
+
+		least, ok := set.SMin("numbers", byInt)
+		assertKeyExists(t, ok)
+		assertCountEqual(t, least.(int), -7)
+
+		reversed := func(a, b interface{}) bool { return byInt(b, a) }
+		greatest, _ = set.SMax("numbers", reversed)
+		assertCountEqual(t, greatest.(int), -7)
+	})
+
+	t.Run("Single-Element Set", func(t *testing.T) {
+		// Test the greatest and least members of a set holding one member.
+		// It ensures that the member is both.
+		set := New()
+		set.SAdd("single", 5)
+
+		greatest, ok := set.SMax("single", byInt)
+		assertKeyExists(t, ok)
+		assertCountEqual(t, greatest.(int), 5)
+
+		least, ok := set.SMin("single", byInt)
+		assertKeyExists(t, ok)
+		assertCountEqual(t, least.(int), 5)
+	})
+
+	t.Run("Empty Set", func(t *testing.T) {
+		// Test the greatest and least members of an empty set and of a non-existent key.
+		// It ensures that nil and false are returned, without calling less.
+		set := New()
+		set.SAdd("empty")
+		panicking := func(a, b interface{}) bool { panic("less called") }
+
+		for _, key := range []string{"empty", "nonexistent"} {
+			if member, ok := set.SMax(key, panicking); member != nil || ok {
+				t.Errorf("Expected (nil, false) from SMax(%q), but got (%v, %v)", key, member, ok)
+			}
+			if member, ok := set.SMin(key, panicking); member != nil || ok {
+				t.Errorf("Expected (nil, false) from SMin(%q), but got (%v, %v)", key, member, ok)
+			}
+		}
+	})
+}
+
+func TestSet_SReduce(t *testing.T) {
+	sum := func(acc, item interface{}) interface{} { return acc.(int) + item.(int) }
+
+	t.Run("Sum of an Integer Set", func(t *testing.T) {
+		// Test summing the members of a set of integers, from two different starting values.
+		// It ensures that every member is folded in exactly once.
+		set := New()
+		set.SAdd("numbers", 1, 2, 3, 4, 5, 10)
+		assertCountEqual(t, set.SReduce("numbers", 0, sum).(int), 25)
+		assertCountEqual(t, set.SReduce("numbers", 100, sum).(int), 125)
+	})
+
+	t.Run("Concatenation of a String Set", func(t *testing.T) {
+		// Test concatenating the members of a set of strings, which depends on the visiting order,
+		// and counting their letters, which does not.
+		// It ensures that the concatenation holds every member, and that the commutative fold is stable across calls.
+		set := New()
+		set.SAdd("words", "ab", "cd", "ef")
+
+		concatenated := set.SReduce("words", "", func(acc, item interface{}) interface{} { return acc.(string) + item.(string) }).(string)
+		assertCountEqual(t, len(concatenated), 6)
+		for _, word := range []string{"ab", "cd", "ef"} {
+			assertKeyExists(t, strings.Contains(concatenated, word))
+		}
+
+		letters := func(acc, item interface{}) interface{} { return acc.(int) + len(item.(string)) }
+		for i := 0; i < 10; i++ {
+			assertCountEqual(t, set.SReduce("words", 0, letters).(int), 6)
+		}
+	})
+
+	t.Run("Missing or Empty Set", func(t *testing.T) {
+		// Test folding over a non-existent key and an empty set.
+		// It ensures that init is returned unchanged, without calling fn.
+		set := New()
+		set.SAdd("empty")
+		panicking := func(acc, item interface{}) interface{} { panic("fn called") }
+
+		assertCountEqual(t, set.SReduce("nonexistent", 7, panicking).(int), 7)
+		assertCountEqual(t, set.SReduce("empty", 7, panicking).(int), 7)
+		if result := set.SReduce("nonexistent", nil, panicking); result != nil {
+			t.Errorf("Expected nil, but got %v", result)
+		}
+	})
+}
+
+func TestSet_SMapStore(t *testing.T) {
+	t.Run("Collapsing Transform", func(t *testing.T) {
+		// Test mapping integers to their parity.
+		// It ensures that the members collapse into two, and that the source is left unchanged.
+		set := New()
+		set.SAdd("numbers", 1, 2, 3, 4, 5, 6, 7)
+
+		count := set.SMapStore("numbers", "parities", func(item interface{}) interface{} { return item.(int) % 2 })
+		assertCountEqual(t, count, 2)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("parities"), []interface{}{0, 1}, "Collapsing Transform")
+		assertSetSize(t, set, "numbers", 7)
+	})
+
+	t.Run("One-to-One Transform", func(t *testing.T) {
+		// Test mapping strings to upper case into an existing destination.
+		// It ensures that every member is transformed and that the destination is overwritten.
+		set := New()
+		set.SAdd("words", "a", "bc", "def")
+		set.SAdd("upper", "stale")
+
+		count := set.SMapStore("words", "upper", func(item interface{}) interface{} { return strings.ToUpper(item.(string)) })
+		assertCountEqual(t, count, 3)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("upper"), []interface{}{"A", "BC", "DEF"}, "One-to-One Transform")
+	})
+
+	t.Run("In Place and Empty Results", func(t *testing.T) {
+		// Test mapping a set onto itself, mapping to non-comparable values, and mapping a non-existent key.
+		// It ensures that the set is replaced in place, and that an empty result deletes the destination.
+		set := New()
+		set.SAdd("numbers", 1, 2)
+		set.SAdd("dest", "stale")
+
+		assertCountEqual(t, set.SMapStore("numbers", "numbers", func(item interface{}) interface{} { return item.(int) * 10 }), 2)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("numbers"), []interface{}{10, 20}, "In Place and Empty Results")
+
+		assertCountEqual(t, set.SMapStore("numbers", "dest", func(item interface{}) interface{} { return []int{item.(int)} }), 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+
+		set.SAdd("dest", "stale")
+		assertCountEqual(t, set.SMapStore("nonexistent", "dest", func(item interface{}) interface{} { return item }), 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+	})
+}
+
+func TestSet_SPartitionStore(t *testing.T) {
+	isEven := func(item interface{}) bool { return item.(int)%2 == 0 }
+
+	t.Run("Parts Cover the Source", func(t *testing.T) {
+		// Test partitioning a set of integers by parity into existing destinations.
+		// It ensures that the counts add up to the size of the source, that the parts are disjoint and hold
+		// the expected members, and that the source is left unchanged.
+		set := New()
+		for i := 1; i <= 9; i++ {
+			set.SAdd("numbers", i)
+		}
+		set.SAdd("even", "stale")
+
+		matched, rest := set.SPartitionStore("numbers", "even", "odd", isEven)
+		assertCountEqual(t, matched, 4)
+		assertCountEqual(t, rest, 5)
+		assertCountEqual(t, matched+rest, set.SCard("numbers"))
+		assertEmptySlice(t, set.SInter("even", "odd"))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("even"), []interface{}{2, 4, 6, 8}, "Parts Cover the Source")
+		assertSlicesEqualIgnoreOrder(t, set.SUnion("even", "odd"), set.SMembers("numbers"), "Parts Cover the Source")
+	})
+
+	t.Run("Empty Part", func(t *testing.T) {
+		// Test partitioning a set whose members all satisfy the predicate, into existing destinations.
+		// It ensures that the empty part deletes its destination.
+		set := New()
+		set.SAdd("numbers", 2, 4)
+		set.SAdd("odd", "stale")
+
+		matched, rest := set.SPartitionStore("numbers", "even", "odd", isEven)
+		assertCountEqual(t, matched, 2)
+		assertCountEqual(t, rest, 0)
+		assertKeyDoesNotExist(t, set.SKeyExists("odd"))
+	})
+
+	t.Run("Source as Destination", func(t *testing.T) {
+		// Test partitioning a set into itself and another key.
+		// It ensures that both parts are computed from the original members.
+		set := New()
+		set.SAdd("numbers", 1, 2, 3, 4)
+
+		matched, rest := set.SPartitionStore("numbers", "numbers", "odd", isEven)
+		assertCountEqual(t, matched, 2)
+		assertCountEqual(t, rest, 2)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("numbers"), []interface{}{2, 4}, "Source as Destination")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("odd"), []interface{}{1, 3}, "Source as Destination")
+	})
+
+	t.Run("Non-Existent Source", func(t *testing.T) {
+		// Test partitioning a non-existent key.
+		// It ensures that both counts are 0 and that no destination is created.
+		set := New()
+		matched, rest := set.SPartitionStore("nonexistent", "even", "odd", isEven)
+		assertCountEqual(t, matched+rest, 0)
+		assertCountEqual(t, len(set.SKeys()), 0)
+	})
+}
+
+func TestSet_SForEach(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c", "d")
+
+	t.Run("Iterate Every Member", func(t *testing.T) {
+		// Test iterating over a set while always continuing.
+		// It ensures that every member is visited exactly once.
+		var visited []interface{}
+		set.SForEach("myset", func(item interface{}) bool {
+			visited = append(visited, item)
+			return true
+		})
+		assertSlicesEqualIgnoreOrder(t, visited, []interface{}{"a", "b", "c", "d"}, "Iterate Every Member")
+	})
+
+	t.Run("Stop Early", func(t *testing.T) {
+		// Test iterating over a set and stopping after the second member.
+		// It ensures that fn is not called again once it returns false.
+		calls := 0
+		set.SForEach("myset", func(item interface{}) bool {
+			calls++
+			return calls < 2
+		})
+		assertCountEqual(t, calls, 2)
+	})
+
+	t.Run("Iterate Non-Existent Set", func(t *testing.T) {
+		// Test iterating over a set that doesn't exist.
+		// It ensures that fn is never called.
+		set.SForEach("nonexistent", func(item interface{}) bool {
+			t.Errorf("Expected fn not to be called, but got %v", item)
+			return true
+		})
+	})
+}
+
+func TestSet_SPopSpecific(t *testing.T) {
+	t.Run("Pop Present and Absent Members", func(t *testing.T) {
+		// Test popping a mix of members that are and aren't in the set.
+		// It ensures that only the present members are returned and the set shrinks by exactly that many.
+		set := New()
+		set.SAdd("myset", "a", "b", "c", "d")
+
+		removed := set.SPopSpecific("myset", "b", "x", "d", "b")
+		assertSlicesEqual(t, removed, []interface{}{"b", "d"})
+		assertSetSize(t, set, "myset", 4-len(removed))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "c"}, "Pop Present and Absent Members")
+	})
+
+	t.Run("Pop Every Member", func(t *testing.T) {
+		// Test popping every member of a set by name.
+		// It ensures that the key is deleted.
+		set := New()
+		set.SAdd("myset", "a", "b")
+
+		assertSlicesEqualIgnoreOrder(t, set.SPopSpecific("myset", "a", "b"), []interface{}{"a", "b"}, "Pop Every Member")
+		assertKeyDoesNotExist(t, set.SKeyExists("myset"))
+	})
+
+	t.Run("Pop from Non-Existent Set", func(t *testing.T) {
+		// Test popping members from a set that doesn't exist.
+		// It ensures that an empty slice is returned and the key is not created.
+		set := New()
+		assertEmptySlice(t, set.SPopSpecific("nonexistent", "a"))
+		assertKeyDoesNotExist(t, set.SKeyExists("nonexistent"))
+	})
+}
+
+func TestSet_SPeek(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c", "d")
+
+	t.Run("Peek Some Members", func(t *testing.T) {
+		// Test peeking at fewer members than the set holds.
+		// It ensures that the requested number of distinct members of the set is returned and none is removed.
+		peeked := set.SPeek("myset", 2)
+		assertCountEqual(t, len(peeked), 2)
+		assertKeyExists(t, peeked[0] != peeked[1])
+		for _, member := range peeked {
+			assertKeyExists(t, set.SIsMember("myset", member))
+		}
+		assertSetSize(t, set, "myset", 4)
+	})
+
+	t.Run("Peek More Members Than Available", func(t *testing.T) {
+		// Test peeking at more members than the set holds.
+		// It ensures that every member is returned and none is removed.
+		assertSlicesEqualIgnoreOrder(t, set.SPeek("myset", 10), []interface{}{"a", "b", "c", "d"}, "Peek More Members Than Available")
+		assertSetSize(t, set, "myset", 4)
+	})
+
+	t.Run("Peek with Non-Positive Count", func(t *testing.T) {
+		// Test peeking with a zero and a negative count.
+		// It ensures that an empty slice is returned in both cases.
+		assertEmptySlice(t, set.SPeek("myset", 0))
+		assertEmptySlice(t, set.SPeek("myset", -1))
+	})
+
+	t.Run("Peek Non-Existent Set", func(t *testing.T) {
+		// Test peeking at a set that doesn't exist.
+		// It ensures that an empty slice is returned.
+		assertEmptySlice(t, set.SPeek("nonexistent", 3))
+	})
+}
+
+func TestSet_SAddChecked(t *testing.T) {
+	type pair struct{ a, b interface{} }
+
+	t.Run("Mixed Comparable and Non-Comparable Members", func(t *testing.T) {
+		// Test adding comparable members mixed with slices, maps, functions and structs holding a slice.
+		// It ensures that nothing panics, that only the comparable members are added, and that the others
+		// are reported in order.
+		set := New()
+		slice, m, fn := []int{1}, map[string]int{"a": 1}, func() {}
+		added, rejected := set.SAddChecked("myset", "a", slice, 1, m, pair{1, 2}, fn, pair{1, slice}, nil)
+
+		assertCountEqual(t, added, 4)
+		assertCountEqual(t, len(rejected), 4)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", 1, pair{1, 2}, nil}, "Mixed Comparable and Non-Comparable Members")
+		if fmt.Sprint(rejected) != fmt.Sprint([]interface{}{slice, m, fn, pair{1, slice}}) {
+			t.Errorf("Expected the rejected members in order, but got %v", rejected)
+		}
+	})
+
+	t.Run("All Members Comparable", func(t *testing.T) {
+		// Test adding only comparable members.
+		// It ensures that nothing is rejected.
+		set := New()
+		added, rejected := set.SAddChecked("myset", "a", "b", "a")
+		assertCountEqual(t, added, 2)
+		if rejected != nil {
+			t.Errorf("Expected no rejected members, but got %v", rejected)
+		}
+	})
+
+	t.Run("SAdd Skips Non-Comparable Members", func(t *testing.T) {
+		// Test adding non-comparable members through SAdd, SAddSlice and MultiSAdd.
+		// It ensures that they are skipped and not counted, without panicking.
+		set := New()
+		assertCountEqual(t, set.SAdd("myset", []string{"x"}, "a", map[int]int{}), 1)
+		assertCountEqual(t, set.SAddSlice("myset", []interface{}{"b", []byte("c")}), 1)
+		assertCountEqual(t, set.MultiSAdd(map[string][]interface{}{"myset": {[]int{}}})["myset"], 0)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b"}, "SAdd Skips Non-Comparable Members")
+	})
+
+	t.Run("SMove Rejects Non-Comparable Members", func(t *testing.T) {
+		// Test moving a slice out of a set.
+		// It ensures that nothing is moved and that SMove returns false instead of panicking.
+		set := New()
+		set.SAdd("src", "a")
+		assertKeyDoesNotExist(t, set.SMove("src", "dest", []int{1}))
+		assertSetSize(t, set, "src", 1)
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+	})
+}
+
+func TestSet_SAddReport(t *testing.T) {
+	t.Run("Duplicates and Existing Members", func(t *testing.T) {
+		// Test adding members that repeat within the input, some of them already in the set.
+		// It ensures that only the new members are reported, once each, in input order.
+		set := New()
+		set.SAdd("myset", "b", "d")
+
+		added := set.SAddReport("myset", "a", "b", "c", "a", "d", "e", "c")
+		assertSlicesEqual(t, added, []interface{}{"a", "c", "e"})
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b", "c", "d", "e"}, "Duplicates and Existing Members")
+	})
+
+	t.Run("New Key", func(t *testing.T) {
+		// Test adding members to a non-existent key.
+		// It ensures that the key is created and every distinct member is reported.
+		set := New()
+		assertSlicesEqual(t, set.SAddReport("myset", 1, 2, 1), []interface{}{1, 2})
+		assertSetSize(t, set, "myset", 2)
+	})
+
+	t.Run("Nothing New", func(t *testing.T) {
+		// Test adding members that are all already in the set, non-comparable members, and no members.
+		// It ensures that an empty slice is returned.
+		set := New()
+		set.SAdd("myset", "a")
+		assertEmptySlice(t, set.SAddReport("myset", "a", []int{1}))
+		assertEmptySlice(t, set.SAddReport("myset"))
+	})
+
+	t.Run("Capped Key", func(t *testing.T) {
+		// Test adding more members than the cap of the key allows.
+		// It ensures that the members left out are not reported.
+		set := New()
+		set.SSetMaxCard("myset", 2)
+		assertSlicesEqual(t, set.SAddReport("myset", "a", "b", "c"), []interface{}{"a", "b"})
+	})
+}
+
+func TestSet_SAddNX(t *testing.T) {
+	t.Run("Create Missing Key", func(t *testing.T) {
+		// Test adding members to a key that does not exist.
+		// It ensures that the key is created with the members and that true is returned.
+		set := New()
+
+		if !set.SAddNX("myset", "a", "b") {
+			t.Error("Expected SAddNX to create the key, but it did not")
+		}
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b"}, "Create Missing Key")
+	})
+
+	t.Run("Existing Key Is Left Unchanged", func(t *testing.T) {
+		// Test calling SAddNX a second time on the same key.
+		// It ensures that no member is added and that false is returned.
+		set := New()
+		set.SAddNX("myset", "a")
+
+		if set.SAddNX("myset", "b") {
+			t.Error("Expected SAddNX to leave the existing key unchanged, but it reported creating it")
+		}
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a"}, "Existing Key Is Left Unchanged")
+	})
+
+	t.Run("No Member Added", func(t *testing.T) {
+		// Test calling SAddNX without members, and with a member that cannot be a map key.
+		// It ensures that the key is not created and that false is returned.
+		set := New()
+
+		if set.SAddNX("myset") || set.SAddNX("myset", []int{1}) {
+			t.Error("Expected SAddNX to report no creation, but it did")
+		}
+		assertKeyDoesNotExist(t, set.SKeyExists("myset"))
+	})
+
+	t.Run("Only One Goroutine Wins", func(t *testing.T) {
+		// Test many goroutines racing to initialize the same key.
+		// It ensures that exactly one of them creates it, and that the set holds only its member.
+		set := New()
+		const goroutines = 50
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		winners := []int{}
+
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				if set.SAddNX("shared", g) {
+					mu.Lock()
+					winners = append(winners, g)
+					mu.Unlock()
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		if len(winners) != 1 {
+			t.Fatalf("Expected exactly one goroutine to create the key, but got %d", len(winners))
+		}
+		assertSlicesEqual(t, set.SMembers("shared"), []interface{}{winners[0]})
+	})
+}
+
+func TestSet_SAddSlice(t *testing.T) {
+	t.Run("Add Slice Like SAdd", func(t *testing.T) {
+		// Test adding the same members with SAddSlice and with SAdd.
+		// It ensures that both return the same count and produce the same set.
+		members := []interface{}{"a", "b", 1, "a", 2.5, 1}
+		set := New()
+
+		assertCountEqual(t, set.SAddSlice("slice", members), set.SAdd("variadic", members...))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("slice"), set.SMembers("variadic"), "Add Slice Like SAdd")
+		assertCountEqual(t, set.SAddSlice("slice", []interface{}{"a", "c"}), 1)
+	})
+
+	t.Run("Add Empty Slice", func(t *testing.T) {
+		// Test adding an empty slice.
+		// It ensures that no member is added.
+		set := New()
+		assertCountEqual(t, set.SAddSlice("myset", nil), 0)
+		assertSetSize(t, set, "myset", 0)
+	})
+
+	t.Run("New Set Seeded with Members", func(t *testing.T) {
+		// Test creating a Set seeded with the members of a slice.
+		// It ensures that the Set holds exactly the given key and members.
+		set := NewWith("myset", []interface{}{"a", "b", "a"})
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"myset"}, "New Set Seeded with Members")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b"}, "New Set Seeded with Members")
+	})
+}
+
+func BenchmarkSet_SAddSlice(b *testing.B) {
+	members := make([]interface{}, 10000)
+	for i := range members {
+		members[i] = i
+	}
+
+	b.Run("SAddSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			New().SAddSlice("myset", members)
+		}
+	})
+
+	b.Run("SAdd", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			New().SAdd("myset", members...)
+		}
+	})
+}
+
+func TestSet_StoreMembers(t *testing.T) {
+	set := New()
+	set.SAdd("set1", "a", "b", "c")
+	set.SAdd("set2", "b", "c", "d")
+
+	t.Run("Union Store Members", func(t *testing.T) {
+		// Test storing a union while getting its members back.
+		// It ensures that the returned members match the stored set.
+		members := set.SUnionStoreMembers("union", "set1", "set2")
+		assertSlicesEqualIgnoreOrder(t, members, []interface{}{"a", "b", "c", "d"}, "Union Store Members")
+		assertSlicesEqualIgnoreOrder(t, members, set.SMembers("union"), "Union Store Members")
+	})
+
+	t.Run("Difference Store Members", func(t *testing.T) {
+		// Test storing a difference while getting its members back.
+		// It ensures that the returned members match the stored set.
+		members := set.SDiffStoreMembers("difference", "set1", "set2")
+		assertSlicesEqualIgnoreOrder(t, members, []interface{}{"a"}, "Difference Store Members")
+		assertSlicesEqualIgnoreOrder(t, members, set.SMembers("difference"), "Difference Store Members")
+	})
+
+	t.Run("Intersection Store Members", func(t *testing.T) {
+		// Test storing an intersection while getting its members back.
+		// It ensures that the returned members match the stored set.
+		members := set.SInterStoreMembers("intersection", "set1", "set2")
+		assertSlicesEqualIgnoreOrder(t, members, []interface{}{"b", "c"}, "Intersection Store Members")
+		assertSlicesEqualIgnoreOrder(t, members, set.SMembers("intersection"), "Intersection Store Members")
+	})
+
+	t.Run("Empty Result", func(t *testing.T) {
+		// Test storing an empty intersection into an existing key while getting its members back.
+		// It ensures that an empty slice is returned and the key is deleted.
+		set.SAdd("dest", "stale")
+		assertEmptySlice(t, set.SInterStoreMembers("dest", "set1", "nonexistent"))
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+	})
+}
+
+func TestSet_MultiSAdd(t *testing.T) {
+	t.Run("Add to Several Keys", func(t *testing.T) {
+		// Test adding members overlapping across keys and with existing members.
+		// It ensures that each key only counts the members newly added to its own set.
+		set := New()
+		set.SAdd("set1", "a")
+
+		added := set.MultiSAdd(map[string][]interface{}{
+			"set1": {"a", "b", "c"},
+			"set2": {"b", "c", "c"},
+			"set3": {"a"},
+		})
+
+		assertCountEqual(t, len(added), 3)
+		assertCountEqual(t, added["set1"], 2)
+		assertCountEqual(t, added["set2"], 2)
+		assertCountEqual(t, added["set3"], 1)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set1"), []interface{}{"a", "b", "c"}, "Add to Several Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set2"), []interface{}{"b", "c"}, "Add to Several Keys")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("set3"), []interface{}{"a"}, "Add to Several Keys")
+	})
+
+	t.Run("Add Empty Input", func(t *testing.T) {
+		// Test adding from an empty map.
+		// It ensures that an empty result is returned and no key is created.
+		set := New()
+		added := set.MultiSAdd(map[string][]interface{}{})
+		assertCountEqual(t, len(added), 0)
+		assertCountEqual(t, len(set.SKeys()), 0)
+	})
+
+	t.Run("Readers See All or Nothing", func(t *testing.T) {
+		// Test reading two keys while they are filled by concurrent bulk adds.
+		// It ensures that a reader never sees the members of one key without those of the other.
+		set := New()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				set.MultiSAdd(map[string][]interface{}{"left": {i}, "right": {i}})
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			set.mu.RLock()
+			left, right := set.get("left").size(), set.get("right").size()
+			set.mu.RUnlock()
+			assertCountEqual(t, left, right)
+		}
+		wg.Wait()
+	})
+}
+
+func TestSet_SCopy(t *testing.T) {
+	t.Run("Copy to New Key", func(t *testing.T) {
+		// Test copying a set into a key that doesn't exist.
+		// It ensures that the destination holds the same members as the source.
+		set := New()
+		set.SAdd("src", "a", "b")
+
+		assertKeyExists(t, set.SCopy("src", "dest", false))
+		assertKeyExists(t, set.SEquals("src", "dest"))
+	})
+
+	t.Run("Refuse Existing Destination", func(t *testing.T) {
+		// Test copying a set into an existing key without replacing it.
+		// It ensures that false is returned and the destination is left unchanged.
+		set := New()
+		set.SAdd("src", "a", "b")
+		set.SAdd("dest", "x")
+
+		assertKeyDoesNotExist(t, set.SCopy("src", "dest", false))
+		assertSlicesEqual(t, set.SMembers("dest"), []interface{}{"x"})
+	})
+
+	t.Run("Replace Existing Destination", func(t *testing.T) {
+		// Test copying a set into an existing key while replacing it.
+		// It ensures that the destination only holds the members of the source.
+		set := New()
+		set.SAdd("src", "a", "b")
+		set.SAdd("dest", "x")
+
+		assertKeyExists(t, set.SCopy("src", "dest", true))
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("dest"), []interface{}{"a", "b"}, "Replace Existing Destination")
+	})
+
+	t.Run("Copy Is Independent", func(t *testing.T) {
+		// Test mutating the source and the copy after copying.
+		// It ensures that the mutations of one set are not visible in the other.
+		set := New()
+		set.SAdd("src", "a", "b")
+		set.SCopy("src", "dest", false)
+
+		set.SAdd("src", "c")
+		set.SRem("dest", "a")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("src"), []interface{}{"a", "b", "c"}, "Copy Is Independent")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("dest"), []interface{}{"b"}, "Copy Is Independent")
+	})
+
+	t.Run("Copy Non-Existent Source", func(t *testing.T) {
+		// Test copying a set that doesn't exist.
+		// It ensures that false is returned and the destination is not created, even when replacing.
+		set := New()
+		assertKeyDoesNotExist(t, set.SCopy("nonexistent", "dest", true))
+		assertKeyDoesNotExist(t, set.SKeyExists("dest"))
+	})
+}
+
+func TestSet_SKeyCount(t *testing.T) {
+	t.Run("Counts of an Empty Set", func(t *testing.T) {
+		// Test counting the keys and members of a Set holding no keys.
+		// It ensures that both counts are 0.
+		set := New()
+		assertCountEqual(t, set.SKeyCount(), 0)
+		assertCountEqual(t, set.STotalCard(), 0)
+	})
+
+	t.Run("Counts of a Populated Set", func(t *testing.T) {
+		// Test counting the keys and members of a Set holding sets of varying sizes.
+		// It ensures that the total matches the sum of the cardinalities of every key.
+		set := New()
+		set.SAdd("small", "a")
+		set.SAdd("medium", "a", "b", "c")
+		for i := 0; i < 50; i++ {
+			set.SAdd("large", i)
+		}
+
+		sum := 0
+		for _, key := range set.SKeys() {
+			sum += set.SCard(key)
+		}
+
+		assertCountEqual(t, set.SKeyCount(), 3)
+		assertCountEqual(t, set.STotalCard(), 54)
+		assertCountEqual(t, set.STotalCard(), sum)
+	})
+}
+
+func TestSet_SUnionParallel(t *testing.T) {
+	t.Run("Parallel Union Matches Union", func(t *testing.T) {
+		// Test the parallel union against the serial union on a randomized corpus of overlapping sets.
+		// It ensures that both return the same members, whatever the number of keys.
+		// GOMAXPROCS is raised so that the keys are split across workers even on a single CPU.
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+		rng := rand.New(rand.NewSource(1))
+		set := New()
+		keys := make([]string, 40)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("set%d", i)
+			for j := 0; j < 200; j++ {
+				set.SAdd(keys[i], rng.Intn(5000))
+			}
+		}
+
+		for _, n := range []int{0, 1, 2, 3, 17, 40} {
+			queried := append(keys[:n:n], "nonexistent")
+			assertSlicesEqualIgnoreOrder(t, set.SUnionParallel(queried...), set.SUnion(queried...), fmt.Sprintf("Parallel Union of %d Sets", n))
+		}
+	})
+}
+
+func BenchmarkSet_SUnionParallel(b *testing.B) {
+	set := New()
+	keys := make([]string, 32)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("set%d", i)
+		members := make([]interface{}, 100000)
+		for j := range members {
+			members[j] = i*50000 + j
+		}
+		set.SAddSlice(keys[i], members)
+	}
+
+	b.Run("SUnion", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set.SUnion(keys...)
+		}
+	})
+
+	b.Run("SUnionParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set.SUnionParallel(keys...)
+		}
+	})
+}
+
+func TestSet_SMembersPage(t *testing.T) {
+	set := New()
+	for i := 0; i < 100; i++ {
+		set.SAdd("myset", i)
+	}
+
+	t.Run("Pages Cover the Set Exactly Once", func(t *testing.T) {
+		// Test paging through 100 members in chunks of 7.
+		// It ensures that every member is returned once, with no overlap between pages.
+		seen := make(map[interface{}]int)
+		pages := 0
+		for offset := 0; ; offset += 7 {
+			page := set.SMembersPage("myset", offset, 7)
+			if len(page) == 0 {
+				break
+			}
+			pages++
+			for _, member := range page {
+				seen[member]++
+			}
+		}
+
+		assertCountEqual(t, pages, 15)
+		assertCountEqual(t, len(seen), 100)
+		for member, times := range seen {
+			if times != 1 {
+				t.Errorf("Expected %v to be returned once, but got %d times", member, times)
+			}
+		}
+	})
+
+	t.Run("Pages Are Stable", func(t *testing.T) {
+		// Test requesting the same page twice, and a page against the sorted members.
+		// It ensures that the order is deterministic and follows the formatted values.
+		assertSlicesEqual(t, set.SMembersPage("myset", 30, 10), set.SMembersPage("myset", 30, 10))
+		assertSlicesEqual(t, set.SMembersPage("myset", 0, 4), []interface{}{0, 1, 10, 11})
+	})
+
+	t.Run("Last Page Is Short", func(t *testing.T) {
+		// Test requesting a page running past the last member.
+		// It ensures that only the remaining members are returned.
+		assertCountEqual(t, len(set.SMembersPage("myset", 95, 10)), 5)
+	})
+
+	t.Run("Unbounded Limit", func(t *testing.T) {
+		// Test a page whose limit would overflow when added to the offset.
+		// It ensures that every member from the offset on is returned without panicking.
+		assertCountEqual(t, len(set.SMembersPage("myset", 1, math.MaxInt)), 99)
+	})
+
+	t.Run("Out-of-Range Offsets and Limits", func(t *testing.T) {
+		// Test pages with an offset past the end or negative, a limit below 1, and a non-existent key.
+		// It ensures that they are all empty.
+		assertEmptySlice(t, set.SMembersPage("myset", 100, 10))
+		assertEmptySlice(t, set.SMembersPage("myset", -1, 10))
+		assertEmptySlice(t, set.SMembersPage("myset", 0, 0))
+		assertEmptySlice(t, set.SMembersPage("myset", 0, -5))
+		assertEmptySlice(t, set.SMembersPage("nonexistent", 0, 10))
+	})
+}
+
+func TestSet_SMembersInto(t *testing.T) {
+	set := New()
+	set.SAdd("myset", "a", "b", "c")
+
+	t.Run("Buffer with Sufficient Capacity", func(t *testing.T) {
+		// Test writing the members into a buffer large enough to hold them, which already holds stale items.
+		// It ensures that the stale items are dropped and the buffer's backing array is reused.
+		buf := make([]interface{}, 2, 8)
+		buf[0], buf[1] = "stale1", "stale2"
+
+		result := set.SMembersInto("myset", buf)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Buffer with Sufficient Capacity")
+		assertKeyExists(t, &result[0] == &buf[:1][0])
+	})
+
+	t.Run("Buffer with Insufficient Capacity", func(t *testing.T) {
+		// Test writing the members into a buffer too small to hold them.
+		// It ensures that the buffer grows to hold every member.
+		result := set.SMembersInto("myset", make([]interface{}, 0, 1))
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Buffer with Insufficient Capacity")
+	})
+
+	t.Run("Nil Buffer", func(t *testing.T) {
+		// Test writing the members into a nil buffer.
+		// It ensures that a new slice holding every member is returned.
+		result := set.SMembersInto("myset", nil)
+		assertSlicesEqualIgnoreOrder(t, result, []interface{}{"a", "b", "c"}, "Nil Buffer")
+	})
+
+	t.Run("Non-Existent Set", func(t *testing.T) {
+		// Test writing the members of a set that doesn't exist.
+		// It ensures that the emptied buffer is returned.
+		result := set.SMembersInto("nonexistent", []interface{}{"stale"})
+		assertCountEqual(t, len(result), 0)
+	})
+}
+
+func BenchmarkSet_SMembersInto(b *testing.B) {
+	set := New()
+	for i := 0; i < 1000; i++ {
+		set.SAdd("myset", i)
+	}
+
+	b.Run("SMembers", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			set.SMembers("myset")
+		}
+	})
+
+	b.Run("SMembersInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []interface{}
+		for i := 0; i < b.N; i++ {
+			buf = set.SMembersInto("myset", buf)
+		}
+	})
 }
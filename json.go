@@ -0,0 +1,90 @@
+package jellyset
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler. The Set is encoded as a JSON object mapping every key to an array
+// of the members of its set, with the members of each array written in a stable order.
+//
+// Members are encoded with encoding/json, so only members of JSON-encodable types can be marshaled.
+//
+// Returns:
+//   - The JSON encoding of the Set.
+//   - An error if a member cannot be encoded as JSON.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1", 2)
+//	data, err := json.Marshal(set)
+//
+// In this example, 'data' will be {"set1":[2,"member1"]}.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make(map[string][]interface{}, len(s.records))
+	for key, set := range s.records {
+		if !s.expired(key) {
+			records[key] = set.sortedList()
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It decodes a JSON object in the shape produced by MarshalJSON
+// and replaces the whole contents of the Set with it. Keys mapped to an empty array are not created.
+// As is the convention for encoding/json, a JSON null is a no-op and leaves the Set unchanged.
+//
+// Members are decoded with encoding/json into interface values, so only strings, numbers, booleans and null
+// round-trip, and every number comes back as a float64. Arrays and objects cannot be set members.
+//
+// Parameters:
+//   - data: 	The JSON encoding of a Set.
+//
+// Returns:
+//   - An error if data is not a JSON object of arrays, or an error wrapping ErrUnsupportedType if a member is
+//     an array or an object. The Set is left unchanged in both cases.
+//
+// Example:
+//
+//	set := New()
+//	err := json.Unmarshal([]byte(`{"set1":["member1",2]}`), set)
+//
+// In this example, "set1" will hold "member1" and float64(2).
+func (s *Set) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var decoded map[string][]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	records := make(map[string]set, len(decoded))
+	for key, members := range decoded {
+		if len(members) == 0 {
+			continue
+		}
+
+		set := newSet()
+		for _, member := range members {
+			switch member.(type) {
+			case []interface{}, map[string]interface{}:
+				return fmt.Errorf("%w: %T cannot be a set member", ErrUnsupportedType, member)
+			}
+			set.add(member)
+		}
+		records[key] = set
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	s.reset(records)
+	return nil
+}
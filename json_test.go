@@ -0,0 +1,86 @@
+package jellyset
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSet_MarshalJSON(t *testing.T) {
+	t.Run("Round Trip String and Numeric Members", func(t *testing.T) {
+		// Test marshaling a Set to JSON and unmarshaling it into a new Set.
+		// It ensures that every key and member survives, with numbers decoded as float64.
+		set := New()
+		set.SAdd("names", "alice", "bob")
+		set.SAdd("scores", 1, 2.5, "three")
+
+		data, err := json.Marshal(set)
+		if err != nil {
+			t.Fatalf("Expected no error while marshaling, but got %v", err)
+		}
+
+		restored := New()
+		if err := json.Unmarshal(data, restored); err != nil {
+			t.Fatalf("Expected no error while unmarshaling, but got %v", err)
+		}
+
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(restored.SKeys()), []interface{}{"names", "scores"}, "Round Trip String and Numeric Members")
+		assertSlicesEqualIgnoreOrder(t, restored.SMembers("names"), []interface{}{"alice", "bob"}, "Round Trip String and Numeric Members")
+		assertSlicesEqualIgnoreOrder(t, restored.SMembers("scores"), []interface{}{1.0, 2.5, "three"}, "Round Trip String and Numeric Members")
+	})
+
+	t.Run("Marshal Empty Set", func(t *testing.T) {
+		// Test marshaling a Set holding no keys.
+		// It ensures that an empty JSON object is produced.
+		data, err := json.Marshal(New())
+		if err != nil {
+			t.Fatalf("Expected no error while marshaling, but got %v", err)
+		}
+		if string(data) != "{}" {
+			t.Errorf("Expected {}, but got %s", data)
+		}
+	})
+
+	t.Run("Unmarshal Replaces Existing Contents", func(t *testing.T) {
+		// Test unmarshaling into a Set that already holds keys.
+		// It ensures that the previous keys are dropped rather than merged with the decoded ones.
+		set := New()
+		set.SAdd("stale", "a")
+		set.SAdd("shared", "b")
+
+		if err := json.Unmarshal([]byte(`{"shared":["c"],"fresh":["d"],"empty":[]}`), set); err != nil {
+			t.Fatalf("Expected no error while unmarshaling, but got %v", err)
+		}
+
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"shared", "fresh"}, "Unmarshal Replaces Existing Contents")
+		assertSlicesEqual(t, set.SMembers("shared"), []interface{}{"c"})
+		assertKeyDoesNotExist(t, set.SKeyExists("stale"))
+	})
+
+	t.Run("Unmarshal Null", func(t *testing.T) {
+		// Test unmarshaling a JSON null into a Set that holds keys.
+		// It ensures that the Set is left unchanged, as encoding/json does for null into a non-pointer value.
+		set := New()
+		set.SAdd("kept", "a")
+		if err := json.Unmarshal([]byte(` null `), set); err != nil {
+			t.Fatalf("Expected no error while unmarshaling, but got %v", err)
+		}
+		assertSlicesEqual(t, set.SMembers("kept"), []interface{}{"a"})
+	})
+
+	t.Run("Unmarshal Unsupported Members", func(t *testing.T) {
+		// Test unmarshaling JSON holding an array or an object as a member.
+		// It ensures that ErrUnsupportedType is returned and the Set is left unchanged.
+		set := New()
+		set.SAdd("myset", "a")
+
+		for _, data := range []string{`{"myset":[["nested"]]}`, `{"myset":[{"nested":1}]}`} {
+			err := json.Unmarshal([]byte(data), set)
+			if !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("Expected ErrUnsupportedType for %s, but got %v", data, err)
+			}
+		}
+
+		assertSlicesEqual(t, set.SMembers("myset"), []interface{}{"a"})
+	})
+}
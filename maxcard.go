@@ -0,0 +1,68 @@
+package jellyset
+
+import "fmt"
+
+// SSetMaxCard caps the number of members of the set associated with the given key, to bound the memory used by
+// a runaway producer. Once the set holds max members, SAdd, SAddSlice, MultiSAdd and SAddBounded stop adding new
+// members to it. A set that already holds more members than max is not shrunk. Other operations, such as SMove
+// or the store operations, are not bounded.
+//
+// The cap belongs to the key rather than to its set: it is kept when the set is emptied or deleted, and applies
+// to the set created the next time the key is added to. A max of 0 or less removes the cap.
+//
+// Parameters:
+//   - key: 	The key to cap.
+//   - max: 	The maximum number of members of the set, or 0 for no limit.
+//
+// Example:
+//
+//	set := New()
+//	set.SSetMaxCard("myset", 2)
+//	count := set.SAdd("myset", "member1", "member2", "member3")
+//
+// In this example, 'count' will be 2 and "member3" is not added.
+func (s *Set) SSetMaxCard(key string, max int) {
+	s.lock()
+	defer s.unlock()
+
+	if max <= 0 {
+		delete(s.maxCards, key)
+		return
+	}
+
+	s.maxCards[key] = max
+}
+
+// SAddBounded adds one or more members to the set associated with the given key, like SAdd,
+// but reports an error when members are left out because the set reached its cap set with SSetMaxCard.
+// The members added before the cap was reached are kept.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - members: One or more members to be added to the set.
+//
+// Returns:
+//   - The number of elements added to the set.
+//   - An error wrapping ErrMaxCardReached if a new member could not be added because the set is full.
+//
+// Example:
+//
+//	set := New()
+//	set.SSetMaxCard("myset", 2)
+//	count, err := set.SAddBounded("myset", "member1", "member2", "member3")
+//
+// In this example, 'count' will be 2 and 'err' wraps ErrMaxCardReached.
+func (s *Set) SAddBounded(key string, members ...interface{}) (int, error) {
+	var added int
+	var full bool
+	mutations := s.mutate(func() {
+		added, full = s.addBounded(key, members...)
+	})
+
+	s.notify(mutations)
+	if full {
+		return added, fmt.Errorf("%w: %q", ErrMaxCardReached, key)
+	}
+
+	return added, nil
+}
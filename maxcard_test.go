@@ -0,0 +1,117 @@
+package jellyset
+
+import "testing"
+
+func TestSet_SSetMaxCard(t *testing.T) {
+	t.Run("Adding Past the Cap in One Call", func(t *testing.T) {
+		// Test adding more members than the cap allows in a single call.
+		// It ensures that only the members fitting under the cap are added and counted.
+		set := New()
+		set.SSetMaxCard("myset", 3)
+
+		assertCountEqual(t, set.SAdd("myset", "a", "b", "c", "d", "e"), 3)
+		assertSetSize(t, set, "myset", 3)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("myset"), []interface{}{"a", "b", "c"}, "Adding Past the Cap in One Call")
+	})
+
+	t.Run("Adding Past the Cap Across Calls", func(t *testing.T) {
+		// Test adding members over several calls, with duplicates, until the cap is exceeded.
+		// It ensures that the set never grows beyond the cap and that existing members can still be added again.
+		set := New()
+		set.SSetMaxCard("myset", 4)
+
+		assertCountEqual(t, set.SAdd("myset", "a", "b"), 2)
+		assertCountEqual(t, set.SAdd("myset", "a", "c"), 1)
+		assertCountEqual(t, set.SAddSlice("myset", []interface{}{"d", "e"}), 1)
+		assertCountEqual(t, set.MultiSAdd(map[string][]interface{}{"myset": {"f"}})["myset"], 0)
+		assertCountEqual(t, set.SAdd("myset", "a", "d"), 0)
+		assertSetSize(t, set, "myset", 4)
+	})
+
+	t.Run("Room Freed by Removal", func(t *testing.T) {
+		// Test removing members from a full set and adding new ones.
+		// It ensures that the freed room can be filled again, and that the cap outlives the deleted set.
+		set := New()
+		set.SSetMaxCard("myset", 2)
+		set.SAdd("myset", "a", "b")
+
+		set.SRem("myset", "a")
+		assertCountEqual(t, set.SAdd("myset", "c", "d"), 1)
+
+		set.SRem("myset", "b", "c")
+		assertCountEqual(t, set.SAdd("myset", "x", "y", "z"), 2)
+		assertSetSize(t, set, "myset", 2)
+	})
+
+	t.Run("Zero Removes the Cap", func(t *testing.T) {
+		// Test capping a key and then setting its cap to 0.
+		// It ensures that the set is no longer limited, and that other keys were never limited.
+		set := New()
+		set.SSetMaxCard("myset", 1)
+		assertCountEqual(t, set.SAdd("other", "a", "b", "c"), 3)
+
+		set.SSetMaxCard("myset", 0)
+		assertCountEqual(t, set.SAdd("myset", "a", "b", "c"), 3)
+	})
+
+	t.Run("Lowered Cap Does Not Shrink the Set", func(t *testing.T) {
+		// Test lowering the cap of a key below the size of its set.
+		// It ensures that no member is removed, but that no new member is added either.
+		set := New()
+		set.SAdd("myset", "a", "b", "c")
+		set.SSetMaxCard("myset", 2)
+
+		assertCountEqual(t, set.SAdd("myset", "d"), 0)
+		assertSetSize(t, set, "myset", 3)
+	})
+
+	t.Run("Clone Keeps the Cap", func(t *testing.T) {
+		// Test cloning a Set with a capped key.
+		// It ensures that the clone is capped the same way.
+		set := New()
+		set.SSetMaxCard("myset", 1)
+		clone := set.SClone()
+
+		assertCountEqual(t, clone.SAdd("myset", "a", "b"), 1)
+	})
+}
+
+func TestSet_SAddBounded(t *testing.T) {
+	t.Run("Members Left Out", func(t *testing.T) {
+		// Test adding more members than the cap allows.
+		// It ensures that the members fitting under the cap are added and that ErrMaxCardReached is returned.
+		set := New()
+		set.SSetMaxCard("myset", 2)
+
+		added, err := set.SAddBounded("myset", "a", "b", "c")
+		assertErrorIs(t, err, ErrMaxCardReached)
+		assertCountEqual(t, added, 2)
+		assertSetSize(t, set, "myset", 2)
+	})
+
+	t.Run("Full Set Without New Members", func(t *testing.T) {
+		// Test adding members already in a full set.
+		// It ensures that no error is returned, since nothing was left out.
+		set := New()
+		set.SSetMaxCard("myset", 2)
+		set.SAdd("myset", "a", "b")
+
+		added, err := set.SAddBounded("myset", "a", "b")
+		if err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+		}
+		assertCountEqual(t, added, 0)
+	})
+
+	t.Run("Uncapped Key", func(t *testing.T) {
+		// Test adding members to a key without a cap.
+		// It ensures that it behaves like SAdd.
+		set := New()
+
+		added, err := set.SAddBounded("myset", "a", "b")
+		if err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+		}
+		assertCountEqual(t, added, 2)
+	})
+}
@@ -0,0 +1,119 @@
+package jellyset
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Estimated costs of the map backing a set, modeled on the Go runtime map layout: a fixed header, plus for
+// every member its interface key, one byte of hash metadata and a share of the bucket overflow pointers.
+const (
+	mapHeaderSize    = 48
+	mapEntryOverhead = int64(unsafe.Sizeof(interface{}(nil))) + 2
+)
+
+// SMemUsage returns an estimate of the number of bytes used by the set associated with the given key.
+// The estimate adds the overhead of the map backing the set to the size of every member, computed from its
+// concrete type: the size of the value itself, plus the bytes referenced by strings and by pointers, arrays
+// and structs holding them. It is meant for rough memory accounting, such as deciding when to evict keys,
+// and does not account for allocator rounding, map growth slack or memory shared between members.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - The estimated number of bytes used by the set, or 0 if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", "member2")
+//	usage := set.SMemUsage("myset")
+//
+// In this example, 'usage' holds the estimated size of "myset" in bytes.
+func (s *Set) SMemUsage(key string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set, ok := s.lookup(key)
+	if !ok {
+		return 0
+	}
+
+	return set.memUsage()
+}
+
+// STotalMemUsage returns an estimate of the number of bytes used by every set of the Set, as the sum of
+// SMemUsage over every key. Like SMemUsage, it is only an estimate.
+//
+// Returns:
+//   - The estimated number of bytes used by every set, or 0 if the Set is empty.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "member1")
+//	set.SAdd("set2", "member2")
+//	usage := set.STotalMemUsage()
+//
+// In this example, 'usage' is the sum of the estimated sizes of "set1" and "set2."
+func (s *Set) STotalMemUsage() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int64
+	for key, set := range s.records {
+		if !s.expired(key) {
+			total += set.memUsage()
+		}
+	}
+
+	return total
+}
+
+// memUsage returns an estimate of the number of bytes used by the set.
+func (s set) memUsage() int64 {
+	usage := int64(mapHeaderSize)
+	for item := range s {
+		usage += mapEntryOverhead + valueSize(reflect.ValueOf(item))
+	}
+
+	return usage
+}
+
+// valueSize returns an estimate of the number of bytes used by v, including the bytes referenced
+// by the strings and pointers it holds.
+func valueSize(v reflect.Value) int64 {
+	if !v.IsValid() {
+		return 0
+	}
+
+	return int64(v.Type().Size()) + referencedSize(v)
+}
+
+// referencedSize returns an estimate of the number of bytes referenced by v, outside of v itself.
+func referencedSize(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return valueSize(v.Elem())
+	case reflect.Array:
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSize(v.Index(i))
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += referencedSize(v.Field(i))
+		}
+		return size
+	default:
+		return 0
+	}
+}
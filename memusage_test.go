@@ -0,0 +1,71 @@
+package jellyset
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSet_SMemUsage(t *testing.T) {
+	t.Run("Usage Grows with Members", func(t *testing.T) {
+		// Test estimating the memory used by a set as members are added to it.
+		// It ensures that the estimate strictly grows with every new member.
+		set := New()
+		set.SAdd("myset", 0)
+		previous := set.SMemUsage("myset")
+
+		for i := 1; i < 20; i++ {
+			set.SAdd("myset", i)
+			usage := set.SMemUsage("myset")
+			if usage <= previous {
+				t.Fatalf("Expected the usage to grow past %d after adding member %d, but got %d", previous, i, usage)
+			}
+			previous = usage
+		}
+	})
+
+	t.Run("Strings Weigh More Than Ints", func(t *testing.T) {
+		// Test estimating the memory used by sets of string and of int members of the same cardinality.
+		// It ensures that the string members, which reference their bytes, count more.
+		set := New()
+		for i := 0; i < 10; i++ {
+			set.SAdd("ints", i)
+			set.SAdd("strings", strings.Repeat("x", 10+i))
+		}
+
+		ints, strs := set.SMemUsage("ints"), set.SMemUsage("strings")
+		if strs <= ints {
+			t.Errorf("Expected string members (%d bytes) to weigh more than int members (%d bytes)", strs, ints)
+		}
+	})
+
+	t.Run("Usage of Structs and Pointers", func(t *testing.T) {
+		// Test estimating the memory used by struct and pointer members holding strings.
+		// It ensures that the referenced strings are counted.
+		type labeled struct {
+			ID    int
+			Label string
+		}
+
+		set := New()
+		set.SAdd("short", labeled{ID: 1, Label: "a"})
+		set.SAdd("long", labeled{ID: 1, Label: strings.Repeat("a", 100)})
+		set.SAdd("pointer", &labeled{ID: 1, Label: strings.Repeat("a", 100)})
+
+		assertCountEqual(t, int(set.SMemUsage("long")-set.SMemUsage("short")), 99)
+		if set.SMemUsage("pointer") <= set.SMemUsage("long") {
+			t.Errorf("Expected a pointer member to weigh more than the value it points to")
+		}
+	})
+
+	t.Run("Total Usage", func(t *testing.T) {
+		// Test estimating the memory used by every set of a Set.
+		// It ensures that the total is the sum of the usage of every key, and 0 for an empty Set.
+		set := New()
+		assertCountEqual(t, int(set.STotalMemUsage()), 0)
+		assertCountEqual(t, int(set.SMemUsage("nonexistent")), 0)
+
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", 1, 2, 3)
+		assertCountEqual(t, int(set.STotalMemUsage()), int(set.SMemUsage("set1")+set.SMemUsage("set2")))
+	})
+}
@@ -0,0 +1,47 @@
+package jellyset
+
+import "expvar"
+
+// expvarMetrics is the JSON document published by PublishExpvar.
+type expvarMetrics struct {
+	Keys           int `json:"keys"`
+	TotalMembers   int `json:"total_members"`
+	LargestKeySize int `json:"largest_key_size"`
+}
+
+// PublishExpvar registers an expvar.Var under the given name exposing live metrics about the Set as JSON:
+// the number of keys, the total number of members across all keys and the size of the largest set.
+// The metrics are computed every time the variable is read, e.g. when /debug/vars is scraped.
+//
+// As with expvar.Publish, it panics if a variable with the same name is already registered.
+//
+// Parameters:
+//   - name: 	The name the metrics are published under.
+//
+// Example:
+//
+//	set := New()
+//	set.PublishExpvar("jellyset")
+//
+// In this example, the metrics of 'set' are exposed under "jellyset" on /debug/vars.
+func (s *Set) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		var metrics expvarMetrics
+		for key, set := range s.records {
+			if s.expired(key) {
+				continue
+			}
+
+			metrics.Keys++
+			metrics.TotalMembers += set.size()
+			if set.size() > metrics.LargestKeySize {
+				metrics.LargestKeySize = set.size()
+			}
+		}
+
+		return metrics
+	}))
+}
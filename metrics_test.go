@@ -0,0 +1,55 @@
+package jellyset
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// expvarCounter keeps published variable names unique when the tests run more than once.
+var expvarCounter atomic.Int64
+
+func TestSet_PublishExpvar(t *testing.T) {
+	set := New()
+	name := fmt.Sprintf("jellyset_test_metrics_%d", expvarCounter.Add(1))
+	set.PublishExpvar(name)
+
+	readMetrics := func(t *testing.T) expvarMetrics {
+		t.Helper()
+		var metrics expvarMetrics
+		if err := json.Unmarshal([]byte(expvar.Get(name).String()), &metrics); err != nil {
+			t.Fatalf("Expected valid JSON metrics, but got %v", err)
+		}
+		return metrics
+	}
+
+	t.Run("Metrics of Empty Set", func(t *testing.T) {
+		// Test reading the published metrics of an empty Set.
+		// It ensures that every metric is zero.
+		metrics := readMetrics(t)
+		assertCountEqual(t, metrics.Keys, 0)
+		assertCountEqual(t, metrics.TotalMembers, 0)
+		assertCountEqual(t, metrics.LargestKeySize, 0)
+	})
+
+	t.Run("Metrics Reflect Mutations", func(t *testing.T) {
+		// Test reading the published metrics after mutating the Set.
+		// It ensures that the published JSON reflects the new counts.
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "d", "e")
+
+		metrics := readMetrics(t)
+		assertCountEqual(t, metrics.Keys, 2)
+		assertCountEqual(t, metrics.TotalMembers, 5)
+		assertCountEqual(t, metrics.LargestKeySize, 3)
+
+		set.SClear("set1")
+
+		metrics = readMetrics(t)
+		assertCountEqual(t, metrics.Keys, 1)
+		assertCountEqual(t, metrics.TotalMembers, 2)
+		assertCountEqual(t, metrics.LargestKeySize, 2)
+	})
+}
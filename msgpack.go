@@ -0,0 +1,276 @@
+package jellyset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// MessagePack format bytes used by the encoder and decoder.
+const (
+	msgpackFalse   = 0xc2
+	msgpackTrue    = 0xc3
+	msgpackFloat32 = 0xca
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackUint16  = 0xcd
+	msgpackUint32  = 0xce
+	msgpackUint64  = 0xcf
+	msgpackInt8    = 0xd0
+	msgpackInt16   = 0xd1
+	msgpackInt32   = 0xd2
+	msgpackInt64   = 0xd3
+	msgpackStr8    = 0xd9
+	msgpackStr16   = 0xda
+	msgpackStr32   = 0xdb
+	msgpackArray16 = 0xdc
+	msgpackArray32 = 0xdd
+)
+
+// SMarshalMsgpack encodes the members of the set associated with the given key as a MessagePack array.
+// Only string, int64, float64 and bool members are supported, each one being written with its own
+// MessagePack type byte. If the key does not exist, an empty array is encoded.
+//
+// Parameters:
+//   - key: 	The key associated with the set to be encoded.
+//
+// Returns:
+//   - The MessagePack encoding of the members.
+//   - An error wrapping ErrUnsupportedType if a member is of another type.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("myset", "member1", int64(2), 3.5, true)
+//	data, err := set.SMarshalMsgpack("myset")
+//
+// In this example, 'data' holds a MessagePack array of the four members.
+func (s *Set) SMarshalMsgpack(key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.get(key)
+	data := appendMsgpackArrayHeader(make([]byte, 0, 5+len(set)*9), len(set))
+
+	for item := range set {
+		switch v := item.(type) {
+		case string:
+			data = appendMsgpackString(data, v)
+		case int64:
+			data = append(data, msgpackInt64)
+			data = binary.BigEndian.AppendUint64(data, uint64(v))
+		case float64:
+			data = append(data, msgpackFloat64)
+			data = binary.BigEndian.AppendUint64(data, math.Float64bits(v))
+		case bool:
+			if v {
+				data = append(data, msgpackTrue)
+			} else {
+				data = append(data, msgpackFalse)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %T cannot be encoded as msgpack", ErrUnsupportedType, item)
+		}
+	}
+
+	return data, nil
+}
+
+// SUnmarshalMsgpack decodes a MessagePack array and adds its members to the set associated with the given key.
+// Strings, booleans, floats and integers of any MessagePack width are accepted; floats are decoded as float64
+// and integers as int64. The data is fully decoded before any member is added, so invalid data leaves the set untouched.
+//
+// Parameters:
+//   - key: 	The key associated with the set the members are added to.
+//   - data: 	The MessagePack encoded members.
+//
+// Returns:
+//   - The number of members added to the set.
+//   - An error wrapping ErrInvalidMsgpack if the data could not be decoded.
+//
+// Example:
+//
+//	set := New()
+//	count, err := set.SUnmarshalMsgpack("restored", data)
+//
+// In this example, the members encoded in 'data' are added to "restored," and 'count' holds how many were added.
+func (s *Set) SUnmarshalMsgpack(key string, data []byte) (int, error) {
+	d := msgpackDecoder{data: data}
+
+	length, err := d.arrayHeader()
+	if err != nil {
+		return 0, err
+	}
+
+	// Every member takes at least one byte, so a longer array cannot be valid, and its length must not be trusted
+	// to size the allocation.
+	if length > len(d.data) {
+		return 0, fmt.Errorf("%w: array of %d members in %d bytes", ErrInvalidMsgpack, length, len(d.data))
+	}
+
+	members := make([]interface{}, 0, length)
+	for i := 0; i < length; i++ {
+		member, err := d.member()
+		if err != nil {
+			return 0, err
+		}
+		members = append(members, member)
+	}
+
+	if len(d.data) != 0 {
+		return 0, fmt.Errorf("%w: %d trailing bytes", ErrInvalidMsgpack, len(d.data))
+	}
+
+	return s.SAdd(key, members...), nil
+}
+
+// appendMsgpackArrayHeader appends the header of a MessagePack array of the given length.
+func appendMsgpackArrayHeader(data []byte, length int) []byte {
+	switch {
+	case length < 16:
+		return append(data, 0x90|byte(length))
+	case length <= math.MaxUint16:
+		data = append(data, msgpackArray16)
+		return binary.BigEndian.AppendUint16(data, uint16(length))
+	default:
+		data = append(data, msgpackArray32)
+		return binary.BigEndian.AppendUint32(data, uint32(length))
+	}
+}
+
+// appendMsgpackString appends a MessagePack string using the smallest format fitting its length.
+func appendMsgpackString(data []byte, str string) []byte {
+	switch {
+	case len(str) < 32:
+		data = append(data, 0xa0|byte(len(str)))
+	case len(str) <= math.MaxUint8:
+		data = append(data, msgpackStr8, byte(len(str)))
+	case len(str) <= math.MaxUint16:
+		data = append(data, msgpackStr16)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(str)))
+	default:
+		data = append(data, msgpackStr32)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(str)))
+	}
+	return append(data, str...)
+}
+
+// msgpackDecoder consumes MessagePack values from the front of data.
+type msgpackDecoder struct {
+	data []byte
+}
+
+// next consumes and returns the next n bytes.
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if len(d.data) < n {
+		return nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidMsgpack)
+	}
+
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+// uint consumes a big-endian unsigned integer of the given size in bytes.
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// arrayHeader consumes an array header and returns the length of the array.
+func (d *msgpackDecoder) arrayHeader() (int, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case b[0]&0xf0 == 0x90:
+		return int(b[0] & 0x0f), nil
+	case b[0] == msgpackArray16:
+		length, err := d.uint(2)
+		return int(length), err
+	case b[0] == msgpackArray32:
+		length, err := d.uint(4)
+		return int(length), err
+	}
+
+	return 0, fmt.Errorf("%w: expected an array, got format 0x%02x", ErrInvalidMsgpack, b[0])
+}
+
+// member consumes a single string, integer, float or boolean value.
+func (d *msgpackDecoder) member() (interface{}, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	format := b[0]
+	switch {
+	case format <= 0x7f:
+		return int64(format), nil
+	case format >= 0xe0:
+		return int64(int8(format)), nil
+	case format&0xe0 == 0xa0:
+		return d.string(int(format & 0x1f))
+	}
+
+	switch format {
+	case msgpackFalse:
+		return false, nil
+	case msgpackTrue:
+		return true, nil
+	case msgpackFloat32:
+		v, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case msgpackFloat64:
+		v, err := d.uint(8)
+		return math.Float64frombits(v), err
+	case msgpackUint8, msgpackUint16, msgpackUint32:
+		v, err := d.uint(1 << (format - msgpackUint8))
+		return int64(v), err
+	case msgpackUint64:
+		v, err := d.uint(8)
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: uint64 %d overflows int64", ErrInvalidMsgpack, v)
+		}
+		return int64(v), err
+	case msgpackInt8:
+		v, err := d.uint(1)
+		return int64(int8(v)), err
+	case msgpackInt16:
+		v, err := d.uint(2)
+		return int64(int16(v)), err
+	case msgpackInt32:
+		v, err := d.uint(4)
+		return int64(int32(v)), err
+	case msgpackInt64:
+		v, err := d.uint(8)
+		return int64(v), err
+	case msgpackStr8, msgpackStr16, msgpackStr32:
+		length, err := d.uint(1 << (format - msgpackStr8))
+		if err != nil {
+			return nil, err
+		}
+		return d.string(int(length))
+	}
+
+	return nil, fmt.Errorf("%w: unsupported format 0x%02x", ErrInvalidMsgpack, format)
+}
+
+// string consumes a string of the given length.
+func (d *msgpackDecoder) string(length int) (interface{}, error) {
+	b, err := d.next(length)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
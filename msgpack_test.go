@@ -0,0 +1,113 @@
+package jellyset
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSet_SMarshalMsgpack(t *testing.T) {
+	set := New()
+
+	t.Run("Round Trip Supported Types", func(t *testing.T) {
+		// Test encoding and decoding a member of every supported type.
+		// It ensures that each member comes back with the same value and type.
+		cases := map[string][]interface{}{
+			"string":  {"", "member1", strings.Repeat("x", 40), strings.Repeat("y", 300), strings.Repeat("z", 70000)},
+			"int64":   {int64(0), int64(1), int64(-1), int64(1 << 40), int64(-1 << 40)},
+			"float64": {0.0, 3.5, -2.25},
+			"bool":    {true, false},
+		}
+
+		for name, members := range cases {
+			set.SAdd(name, members...)
+
+			data, err := set.SMarshalMsgpack(name)
+			if err != nil {
+				t.Fatalf("%s: Expected no error while encoding, but got %v", name, err)
+			}
+
+			count, err := set.SUnmarshalMsgpack(name+"_restored", data)
+			if err != nil {
+				t.Fatalf("%s: Expected no error while decoding, but got %v", name, err)
+			}
+			assertCountEqual(t, count, len(members))
+			assertSlicesEqualIgnoreOrder(t, set.SMembers(name+"_restored"), members, name)
+		}
+	})
+
+	t.Run("Round Trip Large Set", func(t *testing.T) {
+		// Test encoding and decoding a set with more than 15 members.
+		// It ensures that the larger array header is handled.
+		for i := int64(0); i < 100; i++ {
+			set.SAdd("large", i)
+		}
+
+		data, err := set.SMarshalMsgpack("large")
+		if err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+
+		count, err := set.SUnmarshalMsgpack("large_restored", data)
+		if err != nil {
+			t.Fatalf("Expected no error while decoding, but got %v", err)
+		}
+		assertCountEqual(t, count, 100)
+	})
+
+	t.Run("Decode Compact Integers", func(t *testing.T) {
+		// Test decoding integers written by other encoders in their compact formats.
+		// It ensures that they are all decoded as int64.
+		data := []byte{0x94, 0x05, 0xff, msgpackUint8, 0xc8, msgpackInt16, 0xfc, 0x18}
+		count, err := set.SUnmarshalMsgpack("compact", data)
+		if err != nil {
+			t.Fatalf("Expected no error while decoding, but got %v", err)
+		}
+		assertCountEqual(t, count, 4)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("compact"), []interface{}{int64(5), int64(-1), int64(200), int64(-1000)}, "Decode Compact Integers")
+	})
+
+	t.Run("Encode Unsupported Type", func(t *testing.T) {
+		// Test encoding a set holding a member of an unsupported type.
+		// It ensures that an error wrapping ErrUnsupportedType is returned.
+		set.SAdd("unsupported", "member1", 42)
+		_, err := set.SMarshalMsgpack("unsupported")
+		if !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType, but got %v", err)
+		}
+	})
+
+	t.Run("Decode Invalid Data", func(t *testing.T) {
+		// Test decoding truncated data.
+		// It ensures that an error wrapping ErrInvalidMsgpack is returned and the set is untouched.
+		data := []byte{0x92, 0xa3, 'f', 'o', 'o', 0xa3, 'b'}
+		_, err := set.SUnmarshalMsgpack("invalid", data)
+		if !errors.Is(err, ErrInvalidMsgpack) {
+			t.Errorf("Expected ErrInvalidMsgpack, but got %v", err)
+		}
+		assertKeyDoesNotExist(t, set.SKeyExists("invalid"))
+	})
+
+	t.Run("Decode Oversized Array Length", func(t *testing.T) {
+		// Test decoding an array header claiming far more members than the data holds.
+		// It ensures that an error wrapping ErrInvalidMsgpack is returned instead of allocating for the claimed length.
+		data := []byte{0xdd, 0x7f, 0xff, 0xff, 0xff}
+		_, err := set.SUnmarshalMsgpack("oversized", data)
+		if !errors.Is(err, ErrInvalidMsgpack) {
+			t.Errorf("Expected ErrInvalidMsgpack, but got %v", err)
+		}
+		assertKeyDoesNotExist(t, set.SKeyExists("oversized"))
+	})
+
+	t.Run("Encode Non-Existent Set", func(t *testing.T) {
+		// Test encoding a non-existent set.
+		// It ensures that an empty array is encoded.
+		data, err := set.SMarshalMsgpack("nonexistent")
+		if err != nil {
+			t.Fatalf("Expected no error while encoding, but got %v", err)
+		}
+		if len(data) != 1 || data[0] != 0x90 {
+			t.Errorf("Expected an empty msgpack array, but got %x", data)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package jellyset
+
+import (
+	"strings"
+	"time"
+)
+
+// Namespace is a view of a Set in which every key is transparently prefixed, so that several tenants can share
+// one Set without their keys colliding. It holds no data of its own: a set written through the Namespace under
+// key is the set of the underlying Set under prefix+key, and vice versa. It offers the most common operations
+// of Set, which behave as their Set counterparts on the prefixed keys.
+// A Namespace is safe for concurrent use by multiple goroutines.
+type Namespace struct {
+	set    *Set
+	prefix string
+}
+
+// Namespace returns a view of the Set in which every key is prefixed with prefix.
+//
+// Parameters:
+//   - prefix: 	The prefix prepended to every key used through the view.
+//
+// Returns:
+//   - A Namespace sharing the records of the Set.
+//
+// Example:
+//
+//	set := New()
+//	tenant := set.Namespace("tenant1:")
+//	tenant.SAdd("users", "alice")
+//	members := set.SMembers("tenant1:users")
+//
+// In this example, 'members' will contain "alice."
+func (s *Set) Namespace(prefix string) *Namespace {
+	return &Namespace{set: s, prefix: prefix}
+}
+
+// Prefix returns the prefix prepended to every key used through the Namespace.
+func (n *Namespace) Prefix() string {
+	return n.prefix
+}
+
+// key returns the key of the underlying Set for a key of the Namespace.
+func (n *Namespace) key(key string) string {
+	return n.prefix + key
+}
+
+// keys returns the keys of the underlying Set for keys of the Namespace.
+func (n *Namespace) keys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.key(key)
+	}
+
+	return prefixed
+}
+
+// SAdd adds one or more members to the set associated with the key in the Namespace, like Set.SAdd.
+func (n *Namespace) SAdd(key string, members ...interface{}) int {
+	return n.set.SAdd(n.key(key), members...)
+}
+
+// SAddSlice adds the members held in a slice to the set associated with the key in the Namespace, like Set.SAddSlice.
+func (n *Namespace) SAddSlice(key string, members []interface{}) int {
+	return n.set.SAddSlice(n.key(key), members)
+}
+
+// SRem removes one or more members from the set associated with the key in the Namespace, like Set.SRem.
+func (n *Namespace) SRem(key string, members ...interface{}) int {
+	return n.set.SRem(n.key(key), members...)
+}
+
+// SPop removes and returns random members from the set associated with the key in the Namespace, like Set.SPop.
+func (n *Namespace) SPop(key string, count int) []interface{} {
+	return n.set.SPop(n.key(key), count)
+}
+
+// SRandMember returns random members of the set associated with the key in the Namespace, like Set.SRandMember.
+func (n *Namespace) SRandMember(key string, count int) []interface{} {
+	return n.set.SRandMember(n.key(key), count)
+}
+
+// SIsMember checks if the member is in the set associated with the key in the Namespace, like Set.SIsMember.
+func (n *Namespace) SIsMember(key string, member interface{}) bool {
+	return n.set.SIsMember(n.key(key), member)
+}
+
+// SMIsMember checks which members are in the set associated with the key in the Namespace, like Set.SMIsMember.
+func (n *Namespace) SMIsMember(key string, members ...interface{}) []bool {
+	return n.set.SMIsMember(n.key(key), members...)
+}
+
+// SMove moves a member between two sets of the Namespace, like Set.SMove.
+func (n *Namespace) SMove(src, dest string, member interface{}) bool {
+	return n.set.SMove(n.key(src), n.key(dest), member)
+}
+
+// SCard returns the number of members of the set associated with the key in the Namespace, like Set.SCard.
+func (n *Namespace) SCard(key string) int {
+	return n.set.SCard(n.key(key))
+}
+
+// SMembers returns the members of the set associated with the key in the Namespace, like Set.SMembers.
+func (n *Namespace) SMembers(key string) []interface{} {
+	return n.set.SMembers(n.key(key))
+}
+
+// SKeyExists checks if the key exists in the Namespace, like Set.SKeyExists.
+func (n *Namespace) SKeyExists(key string) bool {
+	return n.set.SKeyExists(n.key(key))
+}
+
+// SClear deletes the key from the Namespace, like Set.SClear.
+func (n *Namespace) SClear(key string) {
+	n.set.SClear(n.key(key))
+}
+
+// SKeys returns the keys of the Namespace, without their prefix. The order of the keys is not specified.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("tenant1:users", "alice")
+//	set.SAdd("tenant2:users", "bob")
+//	keys := set.Namespace("tenant1:").SKeys()
+//
+// In this example, 'keys' will contain only "users."
+func (n *Namespace) SKeys() []string {
+	keys := []string{}
+	for _, key := range n.set.SKeys() {
+		if rest, ok := strings.CutPrefix(key, n.prefix); ok {
+			keys = append(keys, rest)
+		}
+	}
+
+	return keys
+}
+
+// SUnion returns the union of sets of the Namespace, like Set.SUnion.
+func (n *Namespace) SUnion(keys ...string) []interface{} {
+	return n.set.SUnion(n.keys(keys)...)
+}
+
+// SUnionStore stores the union of sets of the Namespace into storeKey of the Namespace, like Set.SUnionStore.
+func (n *Namespace) SUnionStore(storeKey string, keys ...string) int {
+	return n.set.SUnionStore(n.key(storeKey), n.keys(keys)...)
+}
+
+// SInter returns the intersection of sets of the Namespace, like Set.SInter.
+func (n *Namespace) SInter(keys ...string) []interface{} {
+	return n.set.SInter(n.keys(keys)...)
+}
+
+// SInterStore stores the intersection of sets of the Namespace into storeKey of the Namespace, like Set.SInterStore.
+func (n *Namespace) SInterStore(storeKey string, keys ...string) int {
+	return n.set.SInterStore(n.key(storeKey), n.keys(keys)...)
+}
+
+// SDiff returns the difference between the first set of the Namespace and the others, like Set.SDiff.
+func (n *Namespace) SDiff(keys ...string) []interface{} {
+	return n.set.SDiff(n.keys(keys)...)
+}
+
+// SDiffStore stores the difference between sets of the Namespace into storeKey of the Namespace, like Set.SDiffStore.
+func (n *Namespace) SDiffStore(storeKey string, keys ...string) int {
+	return n.set.SDiffStore(n.key(storeKey), n.keys(keys)...)
+}
+
+// SExpire sets a time to live on the key of the Namespace, like Set.SExpire.
+func (n *Namespace) SExpire(key string, d time.Duration) bool {
+	return n.set.SExpire(n.key(key), d)
+}
+
+// STTL returns the remaining time to live of the key of the Namespace, like Set.STTL.
+func (n *Namespace) STTL(key string) time.Duration {
+	return n.set.STTL(n.key(key))
+}
+
+// SPersist removes the expiration of the key of the Namespace, like Set.SPersist.
+func (n *Namespace) SPersist(key string) bool {
+	return n.set.SPersist(n.key(key))
+}
@@ -0,0 +1,73 @@
+package jellyset
+
+import "testing"
+
+func TestNamespace(t *testing.T) {
+	t.Run("Writes Through the View", func(t *testing.T) {
+		// Test adding members through a prefixed view.
+		// It ensures that the base Set holds them under the prefixed key, and not under the bare one.
+		set := New()
+		tenant := set.Namespace("tenant1:")
+		assertCountEqual(t, tenant.SAdd("users", "alice", "bob"), 2)
+
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("tenant1:users"), []interface{}{"alice", "bob"}, "Writes Through the View")
+		assertKeyDoesNotExist(t, set.SKeyExists("users"))
+	})
+
+	t.Run("Reads Through the View", func(t *testing.T) {
+		// Test adding members to the base Set under a prefixed key and reading them through the view.
+		// It ensures that the view sees the same set.
+		set := New()
+		set.SAdd("tenant1:users", "alice")
+		tenant := set.Namespace("tenant1:")
+
+		assertKeyExists(t, tenant.SIsMember("users", "alice"))
+		assertCountEqual(t, tenant.SCard("users"), 1)
+		assertKeyDoesNotExist(t, set.Namespace("tenant2:").SKeyExists("users"))
+	})
+
+	t.Run("Keys Are Unprefixed", func(t *testing.T) {
+		// Test listing the keys of views over a Set holding the keys of several tenants.
+		// It ensures that each view lists only its own keys, without the prefix.
+		set := New()
+		set.SAdd("tenant1:users", "alice")
+		set.SAdd("tenant1:admins", "alice")
+		set.SAdd("tenant2:users", "bob")
+		set.SAdd("global", "x")
+
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.Namespace("tenant1:").SKeys()), []interface{}{"users", "admins"}, "Keys Are Unprefixed")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.Namespace("tenant2:").SKeys()), []interface{}{"users"}, "Keys Are Unprefixed")
+		assertCountEqual(t, len(set.Namespace("tenant3:").SKeys()), 0)
+	})
+
+	t.Run("Multi-Key Operations Stay in the Namespace", func(t *testing.T) {
+		// Test set algebra and moves through a view, with a same-named key in another tenant.
+		// It ensures that every key, including the destination, is prefixed.
+		set := New()
+		tenant := set.Namespace("t1:")
+		tenant.SAdd("a", 1, 2, 3)
+		tenant.SAdd("b", 2, 3, 4)
+		set.SAdd("t2:b", 1)
+
+		assertSlicesEqualIgnoreOrder(t, tenant.SInter("a", "b"), []interface{}{2, 3}, "Multi-Key Operations Stay in the Namespace")
+		assertSlicesEqualIgnoreOrder(t, tenant.SDiff("a", "b"), []interface{}{1}, "Multi-Key Operations Stay in the Namespace")
+		assertCountEqual(t, tenant.SUnionStore("all", "a", "b"), 4)
+		assertSetSize(t, set, "t1:all", 4)
+
+		assertKeyExists(t, tenant.SMove("a", "c", 1))
+		assertKeyExists(t, set.SIsMember("t1:c", 1))
+		assertSetSize(t, set, "t2:b", 1)
+	})
+
+	t.Run("Expiration Through the View", func(t *testing.T) {
+		// Test expiring a key through a view.
+		// It ensures that the key of the base Set expires.
+		set := New()
+		tenant := set.Namespace("t1:")
+		tenant.SAdd("session", "a")
+		tenant.SExpire("session", 0)
+
+		assertKeyDoesNotExist(t, set.SKeyExists("t1:session"))
+		assertCountEqual(t, int(tenant.STTL("session")), int(TTLKeyNotFound))
+	})
+}
@@ -0,0 +1,115 @@
+package jellyset
+
+// mutation records a member added to or removed from a set, to be reported to the observers of the Set.
+type mutation struct {
+	added  bool
+	key    string
+	member interface{}
+}
+
+// OnAdd registers fn to be called for every member added to a set by SAdd, SAddSlice, SAddChecked, SAddBounded,
+// SAddReport, SAddNX, SAddReturningSet, MultiSAdd, SMergeFrom, SImport (when merging), SCASMember (for the new
+// member), SMove or SMoveIfAbsent (for the destination set), with the key of the set and the added member. Members that were already in the set
+// are not reported.
+//
+// The callbacks are called after the operation has released its lock and before it returns, so they may call
+// back into the Set. The members are reported in the order they were mutated, and each one to the callbacks
+// in the order they were registered. Callbacks of operations running concurrently may run concurrently.
+// Callbacks cannot be unregistered.
+//
+// Parameters:
+//   - fn: 		The function called with the key and the member of every addition.
+//
+// Example:
+//
+//	set := New()
+//	set.OnAdd(func(key string, member interface{}) {
+//		fmt.Println("added", member, "to", key)
+//	})
+//	set.SAdd("myset", "member1")
+//
+// In this example, "added member1 to myset" is printed.
+func (s *Set) OnAdd(fn func(key string, member interface{})) {
+	s.obsMu.Lock()
+	defer s.obsMu.Unlock()
+
+	s.onAdd = append(s.onAdd, fn)
+	s.observed.Store(true)
+}
+
+// OnRemove registers fn to be called for every member removed from a set by SRem, SPop, SPopE, PopAs, STrim,
+// SPopSpecific, SCASMember (for the old member), SMove or SMoveIfAbsent (for the source set), with the key of
+// the set and the removed member. The callbacks are called as described for OnAdd.
+//
+// Parameters:
+//   - fn: 		The function called with the key and the member of every removal.
+//
+// Example:
+//
+//	set := New()
+//	set.OnRemove(func(key string, member interface{}) {
+//		fmt.Println("removed", member, "from", key)
+//	})
+//	set.SAdd("myset", "member1")
+//	set.SRem("myset", "member1")
+//
+// In this example, "removed member1 from myset" is printed.
+func (s *Set) OnRemove(fn func(key string, member interface{})) {
+	s.obsMu.Lock()
+	defer s.obsMu.Unlock()
+
+	s.onRemove = append(s.onRemove, fn)
+	s.observed.Store(true)
+}
+
+// record queues the mutation of members of the set associated with the key for the observers, if there are any.
+// The caller must hold the write lock.
+func (s *Set) record(added bool, key string, members ...interface{}) {
+	if !s.observed.Load() {
+		return
+	}
+
+	for _, member := range members {
+		s.pending = append(s.pending, mutation{added: added, key: key, member: member})
+	}
+}
+
+// takeMutations returns the queued mutations and clears the queue. The caller must hold the write lock.
+func (s *Set) takeMutations() []mutation {
+	mutations := s.pending
+	s.pending = nil
+	return mutations
+}
+
+// mutate calls fn under the write lock and returns the mutations it queued, to be passed to notify once the lock
+// is released. The lock is released even if fn panics, in which case the queued mutations are discarded.
+func (s *Set) mutate(fn func()) (mutations []mutation) {
+	s.lock()
+	defer s.unlock()
+	defer func() { mutations = s.takeMutations() }()
+
+	fn()
+	return mutations
+}
+
+// notify calls the observers for every mutation. The caller must not hold the lock.
+func (s *Set) notify(mutations []mutation) {
+	if len(mutations) == 0 {
+		return
+	}
+
+	s.obsMu.Lock()
+	onAdd, onRemove := s.onAdd, s.onRemove
+	s.obsMu.Unlock()
+
+	for _, m := range mutations {
+		observers := onRemove
+		if m.added {
+			observers = onAdd
+		}
+
+		for _, fn := range observers {
+			fn(m.key, m.member)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package jellyset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// observedSet returns a Set whose additions and removals are logged as "+key:member" and "-key:member".
+func observedSet() (*Set, *[]interface{}) {
+	set := New()
+	log := &[]interface{}{}
+	set.OnAdd(func(key string, member interface{}) {
+		*log = append(*log, fmt.Sprintf("+%s:%v", key, member))
+	})
+	set.OnRemove(func(key string, member interface{}) {
+		*log = append(*log, fmt.Sprintf("-%s:%v", key, member))
+	})
+	return set, log
+}
+
+func TestSet_Observers(t *testing.T) {
+	t.Run("Add Fires for New Members", func(t *testing.T) {
+		// Test adding members, some of which are already in the set.
+		// It ensures that only the newly added members are reported, with their key.
+		set, log := observedSet()
+		set.SAdd("myset", "a", "b")
+		set.SAdd("myset", "b", "c")
+		assertSlicesEqual(t, *log, []interface{}{"+myset:a", "+myset:b", "+myset:c"})
+	})
+
+	t.Run("Remove Fires for Removed Members", func(t *testing.T) {
+		// Test removing members, some of which are not in the set.
+		// It ensures that only the removed members are reported, with their key.
+		set, log := observedSet()
+		set.SAdd("myset", "a", "b", "c")
+		*log = nil
+
+		set.SRem("myset", "a", "x", "c")
+		assertSlicesEqual(t, *log, []interface{}{"-myset:a", "-myset:c"})
+	})
+
+	t.Run("Pop Fires for Popped Members", func(t *testing.T) {
+		// Test popping random members.
+		// It ensures that exactly the popped members are reported as removed.
+		set, log := observedSet()
+		set.SAdd("myset", "a", "b", "c")
+		*log = nil
+
+		popped := set.SPop("myset", 2)
+		expected := make([]interface{}, len(popped))
+		for i, member := range popped {
+			expected[i] = fmt.Sprintf("-myset:%v", member)
+		}
+		assertSlicesEqual(t, *log, expected)
+	})
+
+	t.Run("Move Fires Remove and Add", func(t *testing.T) {
+		// Test moving a member between two sets, and onto a set already holding it.
+		// It ensures that a removal from the source and an addition to the destination are reported,
+		// but no addition when the destination already holds the member.
+		set, log := observedSet()
+		set.SAdd("src", "a", "b")
+		set.SAdd("dest", "b")
+		*log = nil
+
+		set.SMove("src", "dest", "a")
+		set.SMove("src", "dest", "b")
+		set.SMove("dest", "dest", "a")
+		assertSlicesEqual(t, *log, []interface{}{"-src:a", "+dest:a", "-src:b"})
+	})
+
+	t.Run("Multiple Callbacks in Registration Order", func(t *testing.T) {
+		// Test registering several callbacks for additions.
+		// It ensures that each one is called, in the order they were registered.
+		set := New()
+		var calls []interface{}
+		set.OnAdd(func(key string, member interface{}) { calls = append(calls, "first") })
+		set.OnAdd(func(key string, member interface{}) { calls = append(calls, "second") })
+
+		set.SAdd("myset", "a")
+		assertSlicesEqual(t, calls, []interface{}{"first", "second"})
+	})
+
+	t.Run("Callbacks May Call Back into the Set", func(t *testing.T) {
+		// Test a callback that reads from and writes to the Set it observes.
+		// It ensures that the callback runs outside the lock, without deadlocking.
+		set := New()
+		set.OnAdd(func(key string, member interface{}) {
+			if key == "myset" {
+				set.SAdd("mirror", member)
+				assertKeyExists(t, set.SIsMember("myset", member))
+			}
+		})
+
+		set.SAdd("myset", "a", "b")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("mirror"), []interface{}{"a", "b"}, "Callbacks May Call Back into the Set")
+	})
+
+	t.Run("Failed Typed Pop Fires Nothing", func(t *testing.T) {
+		// Test a typed pop that fails on a member of another type and restores the popped members.
+		// It ensures that neither the removal nor the restoration is reported.
+		set, log := observedSet()
+		set.SAdd("mixed", "a", 1)
+		*log = nil
+
+		if _, err := PopAs[string](set, "mixed", 2); err == nil {
+			t.Fatalf("Expected a type mismatch error")
+		}
+		assertCountEqual(t, len(*log), 0)
+		assertSetSize(t, set, "mixed", 2)
+	})
+
+	t.Run("Named Pop and Swap Fire Remove and Add", func(t *testing.T) {
+		// Test popping named members and swapping a member for another.
+		// It ensures that the removals and the addition are reported, and that a swap with itself reports nothing.
+		set, log := observedSet()
+		set.SAdd("myset", "a", "b", "c")
+		*log = nil
+
+		set.SPopSpecific("myset", "a", "x")
+		set.SCASMember("myset", "b", "d")
+		set.SCASMember("myset", "c", "c")
+		assertSlicesEqual(t, *log, []interface{}{"-myset:a", "-myset:b", "+myset:d"})
+	})
+
+	t.Run("Merges Fire for New Members", func(t *testing.T) {
+		// Test merging another Set and importing members, some of which are already in the set.
+		// It ensures that only the newly added members are reported.
+		set, log := observedSet()
+		set.SAdd("myset", "a")
+		other := New()
+		other.SAdd("myset", "a", "b")
+		set.SMergeFrom(other)
+		set.SImport(map[string][]interface{}{"myset": {"b", "c"}}, false)
+		assertSlicesEqual(t, *log, []interface{}{"+myset:a", "+myset:b", "+myset:c"})
+	})
+
+	t.Run("Panic Releases the Lock", func(t *testing.T) {
+		// Test a mutation that panics while the write lock is held, and is recovered by the caller.
+		// It ensures that the lock is released, so that later calls do not deadlock, and that nothing is reported.
+		set, log := observedSet()
+		set.SAdd("myset", "a")
+		*log = nil
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected SRem to panic on a member that cannot be a map key")
+				}
+			}()
+			set.SRem("myset", "a", []int{1})
+		}()
+
+		done := make(chan struct{})
+		go func() {
+			set.SAdd("myset", "b")
+			set.SRem("myset", "b")
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the lock to be released after the panic, but later calls deadlocked")
+		}
+		assertSlicesEqual(t, *log, []interface{}{"+myset:b", "-myset:b"})
+	})
+}
@@ -0,0 +1,88 @@
+package jellyset
+
+import "sort"
+
+// WithInsertionOrder makes the Set record the order in which members are added to each key, so that
+// SDiffOrdered can return them in that order. Only the members added with SAdd and its variants, or moved
+// with SMove, are recorded; members written by other operations, such as the store operations or SMergeFrom,
+// have no recorded position. Recording costs one map entry per member, which is why it is not the default.
+func WithInsertionOrder() Option {
+	return func(s *Set) {
+		s.order = make(map[string]map[interface{}]uint64)
+	}
+}
+
+// SDiffOrdered returns the difference between the set associated with the first key and the sets associated
+// with the other keys, like SDiff, but in the order in which the members were added to the first key, which
+// requires a Set created with WithInsertionOrder. A member removed and added again takes its new position.
+// Members without a recorded position come last, sorted by their string representation, so that the result
+// is reproducible even without WithInsertionOrder.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets, the first one being the set to subtract from.
+//
+// Returns:
+//   - A slice containing the members of the difference, in insertion order.
+//
+// Example:
+//
+//	set := New(WithInsertionOrder())
+//	set.SAdd("set1", "c", "a", "d", "b")
+//	set.SAdd("set2", "d")
+//	diff := set.SDiffOrdered("set1", "set2")
+//
+// In this example, 'diff' will be ["c", "a", "b"].
+func (s *Set) SDiffOrdered(keys ...string) []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	diff := s.diffMembers(keys...)
+	if len(diff) == 0 {
+		return diff
+	}
+
+	positions := s.order[keys[0]]
+	sort.Slice(diff, func(i, j int) bool {
+		posI, okI := positions[diff[i]]
+		posJ, okJ := positions[diff[j]]
+		if okI != okJ {
+			return okI
+		}
+
+		if okI {
+			return posI < posJ
+		}
+
+		return memberSortKey(diff[i]) < memberSortKey(diff[j])
+	})
+
+	return diff
+}
+
+// trackOrder records the members as the last ones added to the key, if the Set records insertion order.
+// The caller must hold the write lock.
+func (s *Set) trackOrder(key string, members ...interface{}) {
+	if s.order == nil {
+		return
+	}
+
+	positions, ok := s.order[key]
+	if !ok {
+		positions = make(map[interface{}]uint64)
+		s.order[key] = positions
+	}
+
+	for _, member := range members {
+		s.orderSeq++
+		positions[member] = s.orderSeq
+	}
+}
+
+// forgetOrder removes the recorded positions of the members removed from the key.
+// The caller must hold the write lock.
+func (s *Set) forgetOrder(key string, members ...interface{}) {
+	positions := s.order[key]
+	for _, member := range members {
+		delete(positions, member)
+	}
+}
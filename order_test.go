@@ -0,0 +1,80 @@
+package jellyset
+
+import "testing"
+
+func TestSet_SDiffOrdered(t *testing.T) {
+	t.Run("Insertion Order Preserved", func(t *testing.T) {
+		// Test the difference of sets whose first set was filled over several calls in a known order.
+		// It ensures that the members of the difference come in the order they were added.
+		set := New(WithInsertionOrder())
+		set.SAdd("set1", "e", "c")
+		set.SAddSlice("set1", []interface{}{"a", "d"})
+		set.SAdd("set1", "b", "c")
+		set.SAdd("set2", "d")
+
+		assertSlicesEqual(t, set.SDiffOrdered("set1", "set2"), []interface{}{"e", "c", "a", "b"})
+		assertSlicesEqual(t, set.SDiffOrdered("set1"), []interface{}{"e", "c", "a", "d", "b"})
+	})
+
+	t.Run("Removed and Added Again", func(t *testing.T) {
+		// Test removing a member, popping the set empty, and adding members again.
+		// It ensures that a member added again takes its new position rather than its first one.
+		set := New(WithInsertionOrder())
+		set.SAdd("set1", "a", "b", "c")
+		set.SRem("set1", "a")
+		set.SAdd("set1", "a")
+
+		assertSlicesEqual(t, set.SDiffOrdered("set1"), []interface{}{"b", "c", "a"})
+
+		set.SPop("set1", 3)
+		set.SAdd("set1", "c", "b")
+		assertSlicesEqual(t, set.SDiffOrdered("set1"), []interface{}{"c", "b"})
+	})
+
+	t.Run("Moved Members", func(t *testing.T) {
+		// Test moving members into the first set.
+		// It ensures that a moved member takes the last position of the destination set.
+		set := New(WithInsertionOrder())
+		set.SAdd("set1", "b", "a")
+		set.SAdd("other", "c")
+		set.SMove("other", "set1", "c")
+		set.SAdd("set1", "d")
+
+		assertSlicesEqual(t, set.SDiffOrdered("set1"), []interface{}{"b", "a", "c", "d"})
+	})
+
+	t.Run("Members Without a Position", func(t *testing.T) {
+		// Test the difference of a set written by a store operation, and of a Set not recording order.
+		// It ensures that members without a recorded position come sorted by their string representation.
+		set := New(WithInsertionOrder())
+		set.SAdd("src", "c", "a", "b")
+		set.SUnionStore("set1", "src")
+		set.SAdd("set1", "0")
+
+		assertSlicesEqual(t, set.SDiffOrdered("set1"), []interface{}{"0", "a", "b", "c"})
+
+		unordered := New()
+		unordered.SAdd("set1", "c", "a", "b")
+		assertSlicesEqual(t, unordered.SDiffOrdered("set1"), []interface{}{"a", "b", "c"})
+	})
+
+	t.Run("Empty Difference", func(t *testing.T) {
+		// Test the difference with a missing first key and without keys.
+		// It ensures that an empty slice is returned.
+		set := New(WithInsertionOrder())
+
+		assertEmptySlice(t, set.SDiffOrdered("nonexistent_set", "set2"))
+		assertEmptySlice(t, set.SDiffOrdered())
+	})
+
+	t.Run("Clone Keeps the Order", func(t *testing.T) {
+		// Test cloning a Set recording insertion order.
+		// It ensures that the clone keeps the recorded positions and keeps recording new ones.
+		set := New(WithInsertionOrder())
+		set.SAdd("set1", "b", "a")
+		clone := set.SClone()
+		clone.SAdd("set1", "c")
+
+		assertSlicesEqual(t, clone.SDiffOrdered("set1"), []interface{}{"b", "a", "c"})
+	})
+}
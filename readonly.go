@@ -0,0 +1,61 @@
+package jellyset
+
+// ReadOnlySet is a view of a Set that can read its records but not modify them, to be handed to code that
+// must not mutate the Set. It holds no data of its own, so it reflects every change made through the Set.
+// It exposes no method that writes to the records, and its zero value is not usable.
+// A ReadOnlySet is safe for concurrent use by multiple goroutines.
+type ReadOnlySet struct {
+	set *Set
+}
+
+// SReadOnly returns a view of the Set that exposes only its read operations.
+//
+// Returns:
+//   - A ReadOnlySet sharing the records of the Set.
+//
+// Example:
+//
+//	set := New()
+//	view := set.SReadOnly()
+//	set.SAdd("myset", "member1")
+//	count := view.SCard("myset")
+//
+// In this example, the view sees the member added to the Set, and 'count' will be 1.
+func (s *Set) SReadOnly() ReadOnlySet {
+	return ReadOnlySet{set: s}
+}
+
+// SMembers returns all the members of the set associated with the given key, like Set.SMembers.
+func (r ReadOnlySet) SMembers(key string) []interface{} {
+	return r.set.SMembers(key)
+}
+
+// SCard returns the number of members of the set associated with the given key, like Set.SCard.
+func (r ReadOnlySet) SCard(key string) int {
+	return r.set.SCard(key)
+}
+
+// SIsMember checks if the member is in the set associated with the given key, like Set.SIsMember.
+func (r ReadOnlySet) SIsMember(key string, member interface{}) bool {
+	return r.set.SIsMember(key, member)
+}
+
+// SInter returns the intersection of the sets associated with the given keys, like Set.SInter.
+func (r ReadOnlySet) SInter(keys ...string) []interface{} {
+	return r.set.SInter(keys...)
+}
+
+// SUnion returns the union of the sets associated with the given keys, like Set.SUnion.
+func (r ReadOnlySet) SUnion(keys ...string) []interface{} {
+	return r.set.SUnion(keys...)
+}
+
+// SDiff returns the difference between the first set and the others, like Set.SDiff.
+func (r ReadOnlySet) SDiff(keys ...string) []interface{} {
+	return r.set.SDiff(keys...)
+}
+
+// SKeys returns all the keys of the Set, like Set.SKeys.
+func (r ReadOnlySet) SKeys() []string {
+	return r.set.SKeys()
+}
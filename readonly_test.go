@@ -0,0 +1,55 @@
+package jellyset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_SReadOnly(t *testing.T) {
+	t.Run("View Reflects Live Changes", func(t *testing.T) {
+		// Test reading through a view while the base Set is modified.
+		// It ensures that every read operation of the view sees the current records.
+		set := New()
+		view := set.SReadOnly()
+		assertCountEqual(t, view.SCard("set1"), 0)
+
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "b", "c", "d")
+		assertCountEqual(t, view.SCard("set1"), 3)
+		assertSlicesEqualIgnoreOrder(t, view.SMembers("set1"), []interface{}{"a", "b", "c"}, "View Reflects Live Changes")
+		assertSlicesEqualIgnoreOrder(t, view.SInter("set1", "set2"), []interface{}{"b", "c"}, "View Reflects Live Changes")
+		assertSlicesEqualIgnoreOrder(t, view.SUnion("set1", "set2"), []interface{}{"a", "b", "c", "d"}, "View Reflects Live Changes")
+		assertSlicesEqualIgnoreOrder(t, view.SDiff("set1", "set2"), []interface{}{"a"}, "View Reflects Live Changes")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(view.SKeys()), []interface{}{"set1", "set2"}, "View Reflects Live Changes")
+
+		set.SRem("set1", "a")
+		set.SClear("set2")
+		if view.SIsMember("set1", "a") {
+			t.Error("Expected the view to see the removal of \"a\", but it did not")
+		}
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(view.SKeys()), []interface{}{"set1"}, "View Reflects Live Changes")
+	})
+
+	t.Run("No Mutating Method", func(t *testing.T) {
+		// Test the methods exposed by the view, including through a pointer to it.
+		// It ensures that only the read operations are exposed, so that no mutation compiles against the view.
+		allowed := map[string]bool{
+			"SMembers": true, "SCard": true, "SIsMember": true,
+			"SInter": true, "SUnion": true, "SDiff": true, "SKeys": true,
+		}
+
+		viewType := reflect.TypeOf(&ReadOnlySet{})
+		assertCountEqual(t, viewType.NumMethod(), len(allowed))
+		for i := 0; i < viewType.NumMethod(); i++ {
+			if name := viewType.Method(i).Name; !allowed[name] {
+				t.Errorf("Expected only read operations on ReadOnlySet, but found %s", name)
+			}
+		}
+
+		for i := 0; i < viewType.Elem().NumField(); i++ {
+			if field := viewType.Elem().Field(i); field.IsExported() {
+				t.Errorf("Expected the fields of ReadOnlySet to be unexported, but %s is exported", field.Name)
+			}
+		}
+	})
+}
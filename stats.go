@@ -0,0 +1,66 @@
+package jellyset
+
+import "sync/atomic"
+
+// Stats is a snapshot of the operation counters of a Set created with WithStats.
+type Stats struct {
+	// SAdd, SRem, SPop, SInter, SUnion and SDiff count the calls to the method of the same name.
+	SAdd   int64
+	SRem   int64
+	SPop   int64
+	SInter int64
+	SUnion int64
+	SDiff  int64
+
+	// MembersAdded and MembersRemoved count the members added to and removed from a set by any operation reported
+	// to OnAdd and OnRemove, such as SAddSlice, MultiSAdd, STrim or SMove. Sets replaced or deleted as a whole,
+	// for example by a store operation, SClear or an expiration, are not counted.
+	MembersAdded   int64
+	MembersRemoved int64
+}
+
+// opStats holds the live counters behind Stats. They are atomic because SInter, SUnion and SDiff
+// only hold the read lock, which several goroutines may hold at once.
+type opStats struct {
+	sAdd, sRem, sPop, sInter, sUnion, sDiff atomic.Int64
+	membersAdded, membersRemoved            atomic.Int64
+}
+
+// WithStats enables the operation counters reported by Stats. Without it, the operations count nothing
+// and Stats always returns zero counters.
+func WithStats() Option {
+	return func(s *Set) {
+		s.stats = &opStats{}
+	}
+}
+
+// Stats returns a snapshot of how many times SAdd, SRem, SPop, SInter, SUnion and SDiff were called on the Set,
+// and how many members were added and removed. The counters are only kept for a Set created with WithStats.
+//
+// Returns:
+//   - The counters at the time of the call, or zero counters if the Set was not created with WithStats.
+//
+// Example:
+//
+//	set := New(WithStats())
+//	set.SAdd("myset", "member1", "member2")
+//	set.SRem("myset", "member1")
+//	stats := set.Stats()
+//
+// In this example, 'stats' counts one SAdd and one SRem call, two members added and one removed.
+func (s *Set) Stats() Stats {
+	if s.stats == nil {
+		return Stats{}
+	}
+
+	return Stats{
+		SAdd:           s.stats.sAdd.Load(),
+		SRem:           s.stats.sRem.Load(),
+		SPop:           s.stats.sPop.Load(),
+		SInter:         s.stats.sInter.Load(),
+		SUnion:         s.stats.sUnion.Load(),
+		SDiff:          s.stats.sDiff.Load(),
+		MembersAdded:   s.stats.membersAdded.Load(),
+		MembersRemoved: s.stats.membersRemoved.Load(),
+	}
+}
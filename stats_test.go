@@ -0,0 +1,89 @@
+package jellyset
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSet_Stats(t *testing.T) {
+	t.Run("Known Sequence of Operations", func(t *testing.T) {
+		// Test a known sequence of operations, including no-op additions and removals.
+		// It ensures that every call is counted, along with the members actually added and removed.
+		set := New(WithStats())
+		set.SAdd("a", "x", "y", "z")
+		set.SAdd("a", "x", "w")
+		set.SAdd("b", "y")
+		set.SRem("a", "x", "missing")
+		set.SRem("missing", "x")
+		set.SPop("a", 2)
+		set.SPop("missing", 1)
+		set.SInter("a", "b")
+		set.SUnion("a", "b")
+		set.SUnion("a")
+		set.SDiff("a", "b")
+
+		expected := Stats{
+			SAdd: 3, SRem: 2, SPop: 2, SInter: 1, SUnion: 2, SDiff: 1,
+			MembersAdded: 5, MembersRemoved: 3,
+		}
+		if stats := set.Stats(); stats != expected {
+			t.Errorf("Expected %+v, but got %+v", expected, stats)
+		}
+	})
+
+	t.Run("Other Operations Count Only Members", func(t *testing.T) {
+		// Test bulk and named variants of the counted operations, and a store operation.
+		// It ensures that their calls are not counted, but the members they add and remove are, except for the
+		// stored set.
+		set := New(WithStats())
+		set.SAddSlice("a", []interface{}{"x", "y", "z"})
+		set.MultiSAdd(map[string][]interface{}{"b": {"x"}})
+		set.SMove("a", "b", "x")
+		set.SMove("a", "c", "y")
+		set.SAddNX("d", "p", "q")
+		set.STrim("d", 0)
+		set.SPopSpecific("c", "y")
+		set.SInterStore("e", "b", "b")
+
+		expected := Stats{MembersAdded: 7, MembersRemoved: 5}
+		if stats := set.Stats(); stats != expected {
+			t.Errorf("Expected %+v, but got %+v", expected, stats)
+		}
+	})
+
+	t.Run("Counters Disabled by Default", func(t *testing.T) {
+		// Test operations on a Set created without WithStats.
+		// It ensures that Stats reports zero counters.
+		set := New()
+		set.SAdd("a", "x")
+		set.SRem("a", "x")
+		set.SUnion("a")
+
+		if stats := set.Stats(); stats != (Stats{}) {
+			t.Errorf("Expected zero counters, but got %+v", stats)
+		}
+	})
+
+	t.Run("Concurrent Operations", func(t *testing.T) {
+		// Test counted operations running from several goroutines at once, including read-locked ones.
+		// It ensures that no call or member is lost.
+		set := New(WithStats())
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					set.SAdd("a", g*100+i)
+					set.SUnion("a")
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		stats := set.Stats()
+		assertCountEqual(t, int(stats.SAdd), 800)
+		assertCountEqual(t, int(stats.MembersAdded), 800)
+		assertCountEqual(t, int(stats.SUnion), 800)
+	})
+}
@@ -0,0 +1,65 @@
+package jellyset
+
+import (
+	"sort"
+	"strings"
+)
+
+// StringMemberLimit is the number of members of each set written by String before the rest of the set is
+// elided with an ellipsis. A limit of 0 or less writes every member.
+var StringMemberLimit = 10
+
+// String implements fmt.Stringer, summarizing the Set as its keys sorted lexicographically, each followed
+// by the members of its set sorted by their string form, such as {set1: [a b c], set2: [d e]}.
+// Sets holding more than StringMemberLimit members are truncated with an ellipsis.
+//
+// Returns:
+//   - The summary of the Set.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "c", "a", "b")
+//	set.SAdd("set2", "d")
+//	fmt.Println(set)
+//
+// In this example, "{set1: [a b c], set2: [d]}" is printed.
+func (s *Set) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.records))
+	for key := range s.records {
+		if !s.expired(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		members := make([]string, 0, len(s.records[key]))
+		for item := range s.records[key] {
+			members = append(members, memberString(item))
+		}
+		sort.Strings(members)
+
+		if StringMemberLimit > 0 && len(members) > StringMemberLimit {
+			members = append(members[:StringMemberLimit], "...")
+		}
+
+		b.WriteString(key)
+		b.WriteString(": [")
+		b.WriteString(strings.Join(members, " "))
+		b.WriteByte(']')
+	}
+
+	b.WriteByte('}')
+	return b.String()
+}
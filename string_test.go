@@ -0,0 +1,53 @@
+package jellyset
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSet_String(t *testing.T) {
+	t.Run("Format Small Set", func(t *testing.T) {
+		// Test formatting a Set holding a few small sets.
+		// It ensures that keys and members are sorted, and that fmt uses String.
+		set := New()
+		set.SAdd("set2", "e", "d")
+		set.SAdd("set1", "c", "a", "b")
+		set.SAdd("numbers", 10, 2)
+
+		expected := "{numbers: [10 2], set1: [a b c], set2: [d e]}"
+		if actual := fmt.Sprint(set); actual != expected {
+			t.Errorf("Expected %q, but got %q", expected, actual)
+		}
+	})
+
+	t.Run("Format Empty Set", func(t *testing.T) {
+		// Test formatting a Set holding no keys.
+		// It ensures that empty braces are returned.
+		if actual := New().String(); actual != "{}" {
+			t.Errorf("Expected %q, but got %q", "{}", actual)
+		}
+	})
+
+	t.Run("Truncate Large Set", func(t *testing.T) {
+		// Test formatting a set holding more members than the limit.
+		// It ensures that the first members are kept and the rest is elided with an ellipsis.
+		limit := StringMemberLimit
+		defer func() { StringMemberLimit = limit }()
+		StringMemberLimit = 3
+
+		set := New()
+		set.SAdd("large", "a", "b", "c", "d", "e")
+		set.SAdd("small", "x", "y", "z")
+
+		expected := "{large: [a b c ...], small: [x y z]}"
+		if actual := set.String(); actual != expected {
+			t.Errorf("Expected %q, but got %q", expected, actual)
+		}
+
+		StringMemberLimit = 0
+		expected = "{large: [a b c d e], small: [x y z]}"
+		if actual := set.String(); actual != expected {
+			t.Errorf("Expected %q, but got %q", expected, actual)
+		}
+	})
+}
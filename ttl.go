@@ -0,0 +1,357 @@
+package jellyset
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Sentinel durations returned by STTL, mirroring the -1 and -2 replies of Redis TTL.
+const (
+	// TTLNoExpiry is returned by STTL for a key that exists but has no expiration.
+	TTLNoExpiry time.Duration = -1
+
+	// TTLKeyNotFound is returned by STTL for a key that does not exist.
+	TTLKeyNotFound time.Duration = -2
+)
+
+// expireSweepSample is the number of keys holding an expiration that every write examines, deleting those that
+// have expired. As Go randomizes the map iteration order, the sample differs from one write to the next.
+const expireSweepSample = 20
+
+// SExpire sets a time to live on the key, after which the key and its set are deleted. Expiration is lazy:
+// once the time to live has elapsed, every operation treats the key as non-existent, and its set is dropped
+// the next time the key is written or deleted, or when a write to any key samples it among the expiring keys.
+// A time to live that is zero or negative deletes the key at once.
+//
+// The expiration is kept while members are added to or removed from the set, and cleared when the key is
+// deleted or overwritten, for example by a store operation, or with SPersist.
+//
+// Parameters:
+//   - key: 	The key to expire.
+//   - d: 		The time to live of the key.
+//
+// Returns:
+//   - true if the expiration was set, false if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1")
+//	set.SExpire("session", time.Minute)
+//
+// In this example, "session" is deleted one minute later, and the function returns true.
+func (s *Set) SExpire(key string, d time.Duration) bool {
+	s.lock()
+	defer s.unlock()
+
+	if !s.exists(key) {
+		return false
+	}
+
+	if d <= 0 {
+		s.drop(key)
+		return true
+	}
+
+	s.setExpiry(key, s.now().Add(d))
+	return true
+}
+
+// STTL returns the remaining time to live of the key.
+//
+// Parameters:
+//   - key: 	The key whose time to live is returned.
+//
+// Returns:
+//   - The remaining time to live, TTLNoExpiry if the key has no expiration, or TTLKeyNotFound if the key does not exist.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1")
+//	set.SExpire("session", time.Minute)
+//	ttl := set.STTL("session")
+//
+// In this example, 'ttl' will be at most one minute.
+func (s *Set) STTL(key string) time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.exists(key) {
+		return TTLKeyNotFound
+	}
+
+	at, ok := s.expires[key]
+	if !ok {
+		return TTLNoExpiry
+	}
+
+	return at.Sub(s.now())
+}
+
+// AllTTLs returns the remaining time to live of every key that has an expiration, read at a single point in time.
+// Keys without an expiration are omitted, and so are the keys whose time to live has elapsed but that have not
+// been deleted yet, as they no longer exist for any other operation either; every reported duration is positive.
+//
+// Returns:
+//   - A map of the keys that have an expiration to their remaining time to live.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1")
+//	set.SAdd("users", "user1")
+//	set.SExpire("session", time.Minute)
+//	ttls := set.AllTTLs()
+//
+// In this example, 'ttls' will hold "session" with at most one minute, and not "users".
+func (s *Set) AllTTLs() map[string]time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	ttls := make(map[string]time.Duration, len(s.expires))
+	for key, at := range s.expires {
+		if now.Before(at) {
+			ttls[key] = at.Sub(now)
+		}
+	}
+
+	return ttls
+}
+
+// SPersist removes the expiration of the key, so that it is kept until it is explicitly deleted.
+//
+// Parameters:
+//   - key: 	The key whose expiration is removed.
+//
+// Returns:
+//   - true if an expiration was removed, false if the key does not exist or has no expiration.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1")
+//	set.SExpire("session", time.Minute)
+//	persisted := set.SPersist("session")
+//
+// In this example, "session" no longer expires, and 'persisted' will be true.
+func (s *Set) SPersist(key string) bool {
+	s.lock()
+	defer s.unlock()
+
+	if _, ok := s.expires[key]; !ok || !s.exists(key) {
+		return false
+	}
+
+	delete(s.expires, key)
+	return true
+}
+
+// expiry records a key deleted because its time to live had elapsed, to be reported to the callbacks registered
+// with OnExpire.
+type expiry struct {
+	key     string
+	members []interface{}
+}
+
+// OnExpire registers fn to be called for every key deleted because its time to live had elapsed, with the key
+// and the members its set held when it expired. As expiration is lazy, a key is reported when it is actually
+// deleted: when it is written again, swept by a write to any key or by SweepExpired, or replaced by an operation
+// such as SFlush, and not as soon as its time to live elapses. Every expired key is reported exactly once.
+//
+// The callbacks are called after the operation that deleted the key has released its lock, so they may call back
+// into the Set, in the same way as the callbacks registered with OnAdd. Callbacks cannot be unregistered.
+//
+// Parameters:
+//   - fn: 		The function called with the key and the members of every expired key.
+//
+// Example:
+//
+//	set := New()
+//	set.OnExpire(func(key string, members []interface{}) {
+//		fmt.Println(key, "expired with", members)
+//	})
+//	set.SAdd("session", "user1")
+//	set.SExpire("session", time.Minute)
+//	set.SweepExpired(time.Now().Add(time.Hour))
+//
+// In this example, "session expired with [user1]" is printed.
+func (s *Set) OnExpire(fn func(key string, members []interface{})) {
+	s.obsMu.Lock()
+	defer s.obsMu.Unlock()
+
+	s.onExpire = append(s.onExpire, fn)
+	s.expiring.Store(true)
+}
+
+// NextExpiry returns the key that expires first and its expiration time, for example to schedule a sweep at that
+// exact time rather than polling. The keys whose time to live has already elapsed are deleted first, so the
+// reported key is always one that has not expired yet.
+//
+// Returns:
+//   - The key that expires first.
+//   - The time at which it expires.
+//   - true if a key was found, false if no key has an expiration.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1")
+//	set.SAdd("token", "abc")
+//	set.SExpire("session", time.Hour)
+//	set.SExpire("token", time.Minute)
+//	key, at, ok := set.NextExpiry()
+//
+// In this example, 'key' will be "token", 'at' one minute from now, and 'ok' true.
+func (s *Set) NextExpiry() (key string, at time.Time, ok bool) {
+	s.lock()
+	defer s.unlock()
+
+	now := s.now()
+	for s.expiryOrder.Len() > 0 {
+		next := s.expiryOrder[0]
+		if current, ok := s.expires[next.key]; !ok || !current.Equal(next.at) {
+			heap.Pop(&s.expiryOrder)
+			continue
+		}
+
+		if !now.Before(next.at) {
+			heap.Pop(&s.expiryOrder)
+			s.recordExpiry(next.key)
+			s.drop(next.key)
+			continue
+		}
+
+		return next.key, next.at, true
+	}
+
+	return "", time.Time{}, false
+}
+
+// SweepExpired deletes every key whose expiration is not after now, instead of waiting for the keys to be
+// freed lazily, for example to clean up deterministically in tests or before taking a snapshot. Only keys expire
+// in a Set, so a key is deleted along with all of its members.
+//
+// Parameters:
+//   - now: 	The time the expirations are compared to, usually the current time.
+//
+// Returns:
+//   - The total number of members removed, across all the deleted keys.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("session", "user1", "user2")
+//	set.SExpire("session", time.Minute)
+//	removed := set.SweepExpired(time.Now().Add(time.Hour))
+//
+// In this example, "session" has expired an hour later, and 'removed' will be 2.
+func (s *Set) SweepExpired(now time.Time) int {
+	// The lock is taken directly, as lock would already delete a sample of the expired keys without counting them.
+	s.mu.Lock()
+	defer s.unlock()
+
+	s.version++
+	return s.sweepExpired(now, 0)
+}
+
+// sweepExpired deletes the keys whose expiration is not after now, examining at most limit keys holding
+// an expiration, or all of them if limit is 0 or less. It returns the number of members of the deleted keys.
+// The caller must hold the write lock.
+func (s *Set) sweepExpired(now time.Time, limit int) int {
+	examined, removed := 0, 0
+	for key, at := range s.expires {
+		if limit > 0 && examined == limit {
+			break
+		}
+		examined++
+
+		if !now.Before(at) {
+			removed += s.records[key].size()
+			s.recordExpiry(key)
+			s.drop(key)
+		}
+	}
+
+	return removed
+}
+
+// recordExpiry clears the expiration of the expired key and queues it for the callbacks registered with OnExpire,
+// if there are any. Clearing the expiration ensures that the key is queued once; the caller must then delete or
+// replace the key. The caller must hold the write lock.
+func (s *Set) recordExpiry(key string) {
+	delete(s.expires, key)
+	if !s.expiring.Load() {
+		return
+	}
+
+	s.expiries = append(s.expiries, expiry{key: key, members: s.records[key].list()})
+}
+
+// takeExpiries returns the queued expired keys and clears the queue. The caller must hold the write lock.
+func (s *Set) takeExpiries() []expiry {
+	expiries := s.expiries
+	s.expiries = nil
+	return expiries
+}
+
+// notifyExpiries calls the callbacks registered with OnExpire for every expired key. The caller must not hold
+// the lock.
+func (s *Set) notifyExpiries(expiries []expiry) {
+	if len(expiries) == 0 {
+		return
+	}
+
+	s.obsMu.Lock()
+	onExpire := s.onExpire
+	s.obsMu.Unlock()
+
+	for _, e := range expiries {
+		for _, fn := range onExpire {
+			fn(e.key, e.members)
+		}
+	}
+}
+
+// setExpiry sets the expiration of the key to at, and tracks it for NextExpiry. The caller must hold the write lock.
+func (s *Set) setExpiry(key string, at time.Time) {
+	s.expires[key] = at
+
+	// Entries that no longer match expires are left in expiryOrder, so the heap is rebuilt from expires once
+	// most of it is stale, which keeps it proportional to the number of expiring keys.
+	if len(s.expiryOrder) >= 2*len(s.expires) {
+		s.expiryOrder = s.expiryOrder[:0]
+		for key, at := range s.expires {
+			s.expiryOrder = append(s.expiryOrder, expiryEntry{key: key, at: at})
+		}
+		heap.Init(&s.expiryOrder)
+		return
+	}
+
+	heap.Push(&s.expiryOrder, expiryEntry{key: key, at: at})
+}
+
+// expiryEntry is the expiration time of a key, as held by an expiryHeap.
+type expiryEntry struct {
+	key string
+	at  time.Time
+}
+
+// expiryHeap is a min-heap of expirations, earliest first, implementing heap.Interface.
+type expiryHeap []expiryEntry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *expiryHeap) Push(x interface{}) {
+	*h = append(*h, x.(expiryEntry))
+}
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,406 @@
+package jellyset
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose time only moves when advanced.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newClockedSet() (*Set, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return New(WithClock(clock.Now)), clock
+}
+
+func TestSet_SExpire(t *testing.T) {
+	t.Run("Key Expires After Its TTL", func(t *testing.T) {
+		// Test reading a key before and after its time to live elapses.
+		// It ensures that the key is visible until the deadline and treated as non-existent afterwards.
+		set, clock := newClockedSet()
+		set.SAdd("session", "user1", "user2")
+		assertKeyExists(t, set.SExpire("session", time.Minute))
+
+		clock.Advance(59 * time.Second)
+		assertKeyExists(t, set.SKeyExists("session"))
+		assertSetSize(t, set, "session", 2)
+
+		clock.Advance(time.Second)
+		assertKeyDoesNotExist(t, set.SKeyExists("session"))
+		assertSetSize(t, set, "session", 0)
+		assertEmptySlice(t, set.SMembers("session"))
+		assertKeyDoesNotExist(t, set.SIsMember("session", "user1"))
+		assertCountEqual(t, len(set.SKeys()), 0)
+		assertEmptySlice(t, set.SUnion("session"))
+	})
+
+	t.Run("Expire Non-Existent Key", func(t *testing.T) {
+		// Test setting a time to live on a key that doesn't exist.
+		// It ensures that false is returned and no key is created.
+		set, _ := newClockedSet()
+		assertKeyDoesNotExist(t, set.SExpire("nonexistent", time.Minute))
+		assertKeyDoesNotExist(t, set.SKeyExists("nonexistent"))
+	})
+
+	t.Run("Expire with Non-Positive TTL", func(t *testing.T) {
+		// Test setting a time to live of zero.
+		// It ensures that the key is deleted at once.
+		set, _ := newClockedSet()
+		set.SAdd("session", "user1")
+		assertKeyExists(t, set.SExpire("session", 0))
+		assertKeyDoesNotExist(t, set.SKeyExists("session"))
+	})
+
+	t.Run("Re-Add to Expired Key", func(t *testing.T) {
+		// Test adding members to a key whose time to live has elapsed.
+		// It ensures that a fresh key without its old members or expiration is created.
+		set, clock := newClockedSet()
+		set.SAdd("session", "user1")
+		set.SExpire("session", time.Minute)
+		clock.Advance(time.Minute)
+
+		assertCountEqual(t, set.SAdd("session", "user2"), 1)
+		assertSlicesEqual(t, set.SMembers("session"), []interface{}{"user2"})
+		assertCountEqual(t, int(set.STTL("session")), int(TTLNoExpiry))
+	})
+
+	t.Run("Expiration Survives Writes but Not Overwrites", func(t *testing.T) {
+		// Test writing to keys holding a time to live.
+		// It ensures that adding members keeps the expiration while a store operation clears it.
+		set, _ := newClockedSet()
+		set.SAdd("kept", "a")
+		set.SAdd("overwritten", "b")
+		set.SExpire("kept", time.Minute)
+		set.SExpire("overwritten", time.Minute)
+
+		set.SAdd("kept", "c")
+		set.SUnionStore("overwritten", "kept")
+		assertCountEqual(t, int(set.STTL("kept")), int(time.Minute))
+		assertCountEqual(t, int(set.STTL("overwritten")), int(TTLNoExpiry))
+	})
+}
+
+func TestSet_ExpiredKeysAreFreed(t *testing.T) {
+	t.Run("Writes Sweep Expired Keys", func(t *testing.T) {
+		// Test expiring many keys that are never written again, then writing to another key a few times.
+		// It ensures that the expired keys are deleted from the records rather than kept forever,
+		// and that the keys that have not expired yet are kept.
+		set, clock := newClockedSet()
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("session%d", i)
+			set.SAdd(key, "user")
+			set.SExpire(key, time.Minute)
+		}
+		set.SAdd("live", "user")
+		set.SExpire("live", time.Hour)
+
+		clock.Advance(time.Minute)
+		for i := 0; i < 10; i++ {
+			set.SAdd("other", i)
+		}
+
+		assertCountEqual(t, len(set.records), 2)
+		assertCountEqual(t, len(set.expires), 1)
+		assertKeyExists(t, set.SKeyExists("live"))
+	})
+}
+
+// expiringSet returns a clocked Set that logs every key reported to OnExpire as "key:members".
+func expiringSet() (*Set, *fakeClock, *[]interface{}) {
+	set, clock := newClockedSet()
+	expired := &[]interface{}{}
+	set.OnExpire(func(key string, members []interface{}) {
+		sorted := make([]string, len(members))
+		for i, member := range members {
+			sorted[i] = fmt.Sprint(member)
+		}
+		sort.Strings(sorted)
+		*expired = append(*expired, fmt.Sprintf("%s:%v", key, sorted))
+	})
+	return set, clock, expired
+}
+
+func TestSet_OnExpire(t *testing.T) {
+	t.Run("Swept Key Reported Once", func(t *testing.T) {
+		// Test sweeping an expired key twice.
+		// It ensures that the callback receives the key and its final members, and only once.
+		set, clock, expired := expiringSet()
+		set.SAdd("session", "user2", "user1")
+		set.SExpire("session", time.Minute)
+		set.SRem("session", "user2")
+		set.SAdd("session", "user3")
+
+		set.SweepExpired(clock.Now().Add(time.Minute))
+		set.SweepExpired(clock.Now().Add(time.Minute))
+		assertSlicesEqual(t, *expired, []interface{}{"session:[user1 user3]"})
+	})
+
+	t.Run("Key Written Again After Expiring", func(t *testing.T) {
+		// Test writing to a key after its time to live has elapsed, and then writing to it again.
+		// It ensures that the expired set is reported when the write replaces it, and the new set never is.
+		set, clock, expired := expiringSet()
+		set.SAdd("session", "user1")
+		set.SExpire("session", time.Minute)
+		clock.Advance(time.Minute)
+
+		set.SAdd("session", "user2")
+		set.SAdd("session", "user3")
+		set.SRem("session", "user2", "user3")
+		assertSlicesEqual(t, *expired, []interface{}{"session:[user1]"})
+	})
+
+	t.Run("Key Swept by Writes to Other Keys", func(t *testing.T) {
+		// Test writing to another key after a key has expired.
+		// It ensures that the expired key is reported when the write sweeps it.
+		set, clock, expired := expiringSet()
+		set.SAdd("session", "user1")
+		set.SExpire("session", time.Minute)
+		clock.Advance(time.Minute)
+
+		assertEmptySlice(t, *expired)
+		set.SAdd("other", "member1")
+		assertSlicesEqual(t, *expired, []interface{}{"session:[user1]"})
+	})
+
+	t.Run("Live and Persisted Keys Not Reported", func(t *testing.T) {
+		// Test sweeping keys that have not expired, have no expiration, or were made persistent, and deleting a key
+		// before it expires.
+		// It ensures that only keys whose time to live elapses are reported.
+		set, clock, expired := expiringSet()
+		set.SAdd("later", "a")
+		set.SAdd("forever", "b")
+		set.SAdd("persisted", "c")
+		set.SAdd("deleted", "d")
+		set.SExpire("later", time.Hour)
+		set.SExpire("persisted", time.Minute)
+		set.SExpire("deleted", time.Minute)
+		set.SPersist("persisted")
+		set.SRem("deleted", "d")
+
+		set.SweepExpired(clock.Now().Add(time.Minute))
+		assertEmptySlice(t, *expired)
+	})
+
+	t.Run("Callback Calls Back Into the Set", func(t *testing.T) {
+		// Test a callback that reads and writes the Set.
+		// It ensures that the callback runs once the lock has been released.
+		set, clock := newClockedSet()
+		set.OnExpire(func(key string, members []interface{}) {
+			set.SAddSlice("archive", members)
+		})
+		set.SAdd("session", "user1")
+		set.SExpire("session", time.Minute)
+
+		set.SweepExpired(clock.Now().Add(time.Minute))
+		assertSlicesEqual(t, set.SMembers("archive"), []interface{}{"user1"})
+	})
+}
+
+func TestSet_NextExpiry(t *testing.T) {
+	t.Run("Nearest Key Reported and Updated", func(t *testing.T) {
+		// Test several keys with different times to live, as the clock passes each of them.
+		// It ensures that the key expiring first is reported, then the next one once it has expired.
+		set, clock := newClockedSet()
+		start := clock.Now()
+		set.SAdd("hour", "a")
+		set.SAdd("minute", "b")
+		set.SAdd("second", "c")
+		set.SAdd("forever", "d")
+		set.SExpire("hour", time.Hour)
+		set.SExpire("minute", time.Minute)
+		set.SExpire("second", time.Second)
+
+		expected := []struct {
+			key string
+			ttl time.Duration
+		}{{"second", time.Second}, {"minute", time.Minute}, {"hour", time.Hour}}
+		for _, e := range expected {
+			key, at, ok := set.NextExpiry()
+			if !ok || key != e.key || !at.Equal(start.Add(e.ttl)) {
+				t.Fatalf("Expected %s at %v, but got %s at %v (%v)", e.key, start.Add(e.ttl), key, at, ok)
+			}
+			clock.Advance(e.ttl - clock.Now().Sub(start))
+		}
+
+		if key, _, ok := set.NextExpiry(); ok {
+			t.Errorf("Expected no expiring key, but got %s", key)
+		}
+		assertSlicesEqual(t, toInterfaces(set.SKeys()), []interface{}{"forever"})
+	})
+
+	t.Run("Changed and Cleared Expirations", func(t *testing.T) {
+		// Test extending, persisting and deleting keys after their expiration was set.
+		// It ensures that only the current expirations are taken into account.
+		set, clock := newClockedSet()
+		set.SAdd("extended", "a")
+		set.SAdd("persisted", "b")
+		set.SAdd("deleted", "c")
+		set.SAdd("kept", "d")
+		set.SExpire("extended", time.Second)
+		set.SExpire("persisted", time.Second)
+		set.SExpire("deleted", time.Second)
+		set.SExpire("kept", time.Hour)
+		set.SExpire("extended", 2*time.Hour)
+		set.SPersist("persisted")
+		set.SRem("deleted", "c")
+
+		key, at, ok := set.NextExpiry()
+		if !ok || key != "kept" || !at.Equal(clock.Now().Add(time.Hour)) {
+			t.Errorf("Expected kept in an hour, but got %s at %v (%v)", key, at, ok)
+		}
+	})
+
+	t.Run("No Expiring Key", func(t *testing.T) {
+		// Test a Set whose keys have no expiration.
+		// It ensures that no key is reported.
+		set := New()
+		set.SAdd("myset", "a")
+		if key, _, ok := set.NextExpiry(); ok {
+			t.Errorf("Expected no expiring key, but got %s", key)
+		}
+	})
+
+	t.Run("Repeated Expirations Stay Bounded", func(t *testing.T) {
+		// Test setting the expiration of the same key many times.
+		// It ensures that the outdated expirations are discarded rather than accumulated.
+		set, _ := newClockedSet()
+		set.SAdd("session", "a")
+		for i := 1; i <= 1000; i++ {
+			set.SExpire("session", time.Duration(i)*time.Second)
+		}
+
+		if len(set.expiryOrder) > 2 {
+			t.Errorf("Expected at most 2 tracked expirations, but got %d", len(set.expiryOrder))
+		}
+		if key, _, ok := set.NextExpiry(); !ok || key != "session" {
+			t.Errorf("Expected session, but got %s (%v)", key, ok)
+		}
+	})
+}
+
+func TestSet_SweepExpired(t *testing.T) {
+	t.Run("Mix of Expired and Live Keys", func(t *testing.T) {
+		// Test sweeping keys that expired, keys that expire later, and keys without an expiration.
+		// It ensures that only the expired keys are deleted, and that all of their members are counted.
+		set, clock := newClockedSet()
+		set.SAdd("expired1", "a", "b")
+		set.SAdd("expired2", "c", "f", "g")
+		set.SAdd("later", "d")
+		set.SAdd("forever", "e")
+		set.SExpire("expired1", time.Minute)
+		set.SExpire("expired2", 2*time.Minute)
+		set.SExpire("later", time.Hour)
+
+		assertCountEqual(t, set.SweepExpired(clock.Now().Add(2*time.Minute)), 5)
+		assertCountEqual(t, len(set.records), 2)
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(set.SKeys()), []interface{}{"later", "forever"}, "Mix of Expired and Live Keys")
+		assertCountEqual(t, set.SweepExpired(clock.Now().Add(2*time.Minute)), 0)
+	})
+
+	t.Run("Sweep Relative to the Given Time", func(t *testing.T) {
+		// Test sweeping at a time before the expiration, although the clock of the Set is past it.
+		// It ensures that the given time, not the clock, decides what is swept.
+		set, clock := newClockedSet()
+		set.SAdd("session", "a")
+		set.SExpire("session", time.Minute)
+		start := clock.Now()
+		clock.Advance(time.Hour)
+
+		assertCountEqual(t, set.SweepExpired(start), 0)
+		assertCountEqual(t, len(set.records), 1)
+	})
+}
+
+func TestSet_STTL(t *testing.T) {
+	t.Run("TTL of Keys", func(t *testing.T) {
+		// Test reading the time to live of missing, persistent and expiring keys.
+		// It ensures that the sentinels are told apart and that the remaining time decreases with the clock.
+		set, clock := newClockedSet()
+		set.SAdd("persistent", "a")
+		set.SAdd("expiring", "b")
+		set.SExpire("expiring", time.Minute)
+
+		assertCountEqual(t, int(set.STTL("nonexistent")), int(TTLKeyNotFound))
+		assertCountEqual(t, int(set.STTL("persistent")), int(TTLNoExpiry))
+		assertCountEqual(t, int(set.STTL("expiring")), int(time.Minute))
+
+		clock.Advance(20 * time.Second)
+		assertCountEqual(t, int(set.STTL("expiring")), int(40*time.Second))
+
+		clock.Advance(40 * time.Second)
+		assertCountEqual(t, int(set.STTL("expiring")), int(TTLKeyNotFound))
+	})
+}
+
+func TestSet_AllTTLs(t *testing.T) {
+	t.Run("Only Expiring Keys Reported", func(t *testing.T) {
+		// Test keys with and without a time to live, one of which has expired but was not deleted yet.
+		// It ensures that only the live keys with a time to live are reported, with their remaining time.
+		set, clock := newClockedSet()
+		set.SAdd("session", "a")
+		set.SAdd("token", "b")
+		set.SAdd("expired", "c")
+		set.SAdd("forever", "d")
+		set.SExpire("session", time.Hour)
+		set.SExpire("token", 2*time.Minute)
+		set.SExpire("expired", time.Minute)
+		clock.Advance(time.Minute)
+
+		ttls := set.AllTTLs()
+		assertCountEqual(t, len(ttls), 2)
+		if ttls["session"] != 59*time.Minute || ttls["token"] != time.Minute {
+			t.Errorf("Expected session in 59m and token in 1m, but got %v", ttls)
+		}
+	})
+
+	t.Run("No Expiring Key", func(t *testing.T) {
+		// Test a Set whose keys have no expiration.
+		// It ensures that an empty map is returned.
+		set := New()
+		set.SAdd("myset", "a")
+		assertCountEqual(t, len(set.AllTTLs()), 0)
+	})
+}
+
+func TestSet_SPersist(t *testing.T) {
+	t.Run("Persist Expiring Key", func(t *testing.T) {
+		// Test removing the expiration of a key.
+		// It ensures that the key outlives its former deadline.
+		set, clock := newClockedSet()
+		set.SAdd("session", "user1")
+		set.SExpire("session", time.Minute)
+
+		assertKeyExists(t, set.SPersist("session"))
+		assertCountEqual(t, int(set.STTL("session")), int(TTLNoExpiry))
+
+		clock.Advance(time.Hour)
+		assertKeyExists(t, set.SKeyExists("session"))
+		assertSetSize(t, set, "session", 1)
+	})
+
+	t.Run("Persist Key Without Expiration", func(t *testing.T) {
+		// Test persisting keys that have no expiration or do not exist.
+		// It ensures that false is returned in both cases.
+		set, clock := newClockedSet()
+		set.SAdd("persistent", "a")
+		set.SAdd("expired", "b")
+		set.SExpire("expired", time.Minute)
+		clock.Advance(time.Minute)
+
+		assertKeyDoesNotExist(t, set.SPersist("persistent"))
+		assertKeyDoesNotExist(t, set.SPersist("nonexistent"))
+		assertKeyDoesNotExist(t, set.SPersist("expired"))
+	})
+}
@@ -0,0 +1,661 @@
+package jellyset
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// TypedSet is a type-safe variant of Set whose members are all of type T. It offers the same operations as Set,
+// prefixed with T instead of S, without boxing members into interfaces. A TypedSet shares no state with any Set.
+// A TypedSet is safe for concurrent use by multiple goroutines.
+type TypedSet[T comparable] struct {
+	mu      sync.RWMutex
+	records map[string]map[T]struct{}
+
+	// rngMu guards rng, which TRandMember uses under the read lock.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+// NewTyped creates and returns a new empty TypedSet, whose random members are drawn from a source seeded with
+// the current time.
+//
+// Returns:
+//   - A new empty TypedSet.
+//
+// Example:
+//
+//	ids := NewTyped[int]()
+//	ids.TAdd("active", 1, 2, 3)
+//
+// In this example, 'ids' holds the int members 1, 2 and 3 under "active".
+func NewTyped[T comparable]() *TypedSet[T] {
+	return &TypedSet[T]{
+		records: make(map[string]map[T]struct{}),
+		rng:     newDefaultRand(),
+	}
+}
+
+// TAdd adds one or more members to the set associated with the provided key, creating it if needed.
+// It is the typed counterpart of SAdd.
+//
+// Parameters:
+//   - key: 		The key associated with the set.
+//   - members: 	The members to add to the set.
+//
+// Returns:
+//   - The number of members added to the set, not counting those already in it.
+//
+// Example:
+//
+//	set := NewTyped[string]()
+//	added := set.TAdd("myset", "member1", "member2", "member1")
+//
+// In this example, 'added' will be 2.
+func (s *TypedSet[T]) TAdd(key string, members ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.records[key]
+	if !ok {
+		set = make(map[T]struct{})
+	}
+
+	added := 0
+	for _, member := range members {
+		if _, exists := set[member]; !exists {
+			set[member] = keyExists
+			added++
+		}
+	}
+
+	// The key is only created once it has a member, as an empty set is never kept under a key.
+	if added > 0 {
+		s.records[key] = set
+	}
+
+	return added
+}
+
+// TRem removes one or more members from the set associated with the given key, deleting the key once it is empty.
+// It is the typed counterpart of SRem.
+//
+// Parameters:
+//   - key: 		The key associated with the set.
+//   - members: 	The members to remove from the set.
+//
+// Returns:
+//   - The number of members removed from the set.
+//
+// Example:
+//
+//	set := NewTyped[string]()
+//	set.TAdd("myset", "member1", "member2")
+//	removed := set.TRem("myset", "member1", "member3")
+//
+// In this example, 'removed' will be 1.
+func (s *TypedSet[T]) TRem(key string, members ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set := s.records[key]
+	removed := 0
+
+	for _, member := range members {
+		if _, exists := set[member]; exists {
+			delete(set, member)
+			removed++
+		}
+	}
+
+	if removed > 0 && len(set) == 0 {
+		delete(s.records, key)
+	}
+
+	return removed
+}
+
+// TIsMember checks if the specified member exists in the set associated with the given key.
+// It is the typed counterpart of SIsMember.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - member: 	The member to look for.
+//
+// Returns:
+//   - true if the member is in the set, false otherwise or if the key does not exist.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2)
+//	exists := set.TIsMember("numbers", 2)
+//
+// In this example, 'exists' will be true.
+func (s *TypedSet[T]) TIsMember(key string, member T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.records[key][member]
+	return exists
+}
+
+// TMIsMember checks the membership of several members at once, aligned positionally with the input.
+// It is the typed counterpart of SMIsMember.
+//
+// Parameters:
+//   - key: 		The key associated with the set.
+//   - members: 	The members to look for.
+//
+// Returns:
+//   - A slice holding, for each member, whether it is in the set.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2)
+//	exists := set.TMIsMember("numbers", 1, 3, 2)
+//
+// In this example, 'exists' will be [true false true].
+func (s *TypedSet[T]) TMIsMember(key string, members ...T) []bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := s.records[key]
+	exists := make([]bool, len(members))
+
+	for i, member := range members {
+		_, exists[i] = set[member]
+	}
+
+	return exists
+}
+
+// TMove moves a member from the source set to the destination set, creating the destination if needed
+// and deleting the source once it is empty. It is the typed counterpart of SMove.
+//
+// Parameters:
+//   - src: 	The key associated with the source set.
+//   - dest: 	The key associated with the destination set.
+//   - member: 	The member to move.
+//
+// Returns:
+//   - true if the member was moved, false if it is not in the source set.
+//
+// Example:
+//
+//	set := NewTyped[string]()
+//	set.TAdd("todo", "task1")
+//	moved := set.TMove("todo", "done", "task1")
+//
+// In this example, "task1" is moved to "done", "todo" is deleted, and 'moved' will be true.
+func (s *TypedSet[T]) TMove(src, dest string, member T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.records[src][member]; !exists {
+		return false
+	}
+
+	destSet, ok := s.records[dest]
+	if !ok {
+		destSet = make(map[T]struct{})
+		s.records[dest] = destSet
+	}
+
+	delete(s.records[src], member)
+	destSet[member] = keyExists
+
+	if len(s.records[src]) == 0 {
+		delete(s.records, src)
+	}
+
+	return true
+}
+
+// TCard returns the number of members in the set associated with the given key.
+// It is the typed counterpart of SCard.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - The number of members in the set, or 0 if the key does not exist.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2, 3)
+//	count := set.TCard("numbers")
+//
+// In this example, 'count' will be 3.
+func (s *TypedSet[T]) TCard(key string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.records[key])
+}
+
+// TMembers returns all the members of the set associated with the given key, in no particular order.
+// It is the typed counterpart of SMembers.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//
+// Returns:
+//   - A slice containing the members of the set, or an empty slice if the key does not exist.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2)
+//	members := set.TMembers("numbers")
+//
+// In this example, 'members' will contain 1 and 2.
+func (s *TypedSet[T]) TMembers(key string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return typedList(s.records[key])
+}
+
+// TKeyExists checks if the specified key exists in the TypedSet.
+// It is the typed counterpart of SKeyExists.
+//
+// Parameters:
+//   - key: 	The key to look for.
+//
+// Returns:
+//   - true if the key exists, false otherwise.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1)
+//	exists := set.TKeyExists("numbers")
+//
+// In this example, 'exists' will be true.
+func (s *TypedSet[T]) TKeyExists(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.records[key]
+	return exists
+}
+
+// TClear deletes the specified key and its associated set.
+// It is the typed counterpart of SClear.
+//
+// Parameters:
+//   - key: 	The key to delete.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2)
+//	set.TClear("numbers")
+//
+// In this example, "numbers" no longer exists.
+func (s *TypedSet[T]) TClear(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+}
+
+// TUnion returns the union of the sets associated with the given keys. Non-existent keys contribute no members.
+// It is the typed counterpart of SUnion.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to combine.
+//
+// Returns:
+//   - A slice containing the members present in any of the sets.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	union := set.TUnion("set1", "set2")
+//
+// In this example, 'union' will contain 1, 2 and 3.
+func (s *TypedSet[T]) TUnion(keys ...string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return typedList(s.union(keys...))
+}
+
+// TUnionStore stores the union of the sets associated with the given keys into storeKey,
+// replacing it, and deleting it if the union is empty. It is the typed counterpart of SUnionStore.
+//
+// Parameters:
+//   - storeKey: 	The key where the union is stored.
+//   - keys: 		The keys associated with the sets to combine.
+//
+// Returns:
+//   - The number of members in the stored union.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	count := set.TUnionStore("all", "set1", "set2")
+//
+// In this example, "all" holds 1, 2 and 3, and 'count' will be 3.
+func (s *TypedSet[T]) TUnionStore(storeKey string, keys ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.store(storeKey, s.union(keys...))
+}
+
+// TInter returns the intersection of the sets associated with the given keys.
+// If any key does not exist, the intersection is empty. It is the typed counterpart of SInter.
+//
+// Parameters:
+//   - keys: 	The keys associated with the sets to intersect.
+//
+// Returns:
+//   - A slice containing the members present in every set.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	inter := set.TInter("set1", "set2")
+//
+// In this example, 'inter' will be [2].
+func (s *TypedSet[T]) TInter(keys ...string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return typedList(s.inter(keys...))
+}
+
+// TInterStore stores the intersection of the sets associated with the given keys into storeKey,
+// replacing it, and deleting it if the intersection is empty. It is the typed counterpart of SInterStore.
+//
+// Parameters:
+//   - storeKey: 	The key where the intersection is stored.
+//   - keys: 		The keys associated with the sets to intersect.
+//
+// Returns:
+//   - The number of members in the stored intersection.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	count := set.TInterStore("common", "set1", "set2")
+//
+// In this example, "common" holds 2, and 'count' will be 1.
+func (s *TypedSet[T]) TInterStore(storeKey string, keys ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.store(storeKey, s.inter(keys...))
+}
+
+// TDiff returns the members of the first set that are not in any of the other sets.
+// It is the typed counterpart of SDiff, with the same semantics: the other keys equal to the first one are
+// ignored, and the difference is empty if any of the other keys does not exist.
+//
+// Parameters:
+//   - keys: 	The key of the set to subtract from, followed by the keys of the sets to subtract.
+//
+// Returns:
+//   - A slice containing the members of the first set that are in none of the others.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	diff := set.TDiff("set1", "set2")
+//
+// In this example, 'diff' will be [1].
+func (s *TypedSet[T]) TDiff(keys ...string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return typedList(s.diff(keys...))
+}
+
+// TDiffStore stores the difference between the first set and the others into storeKey,
+// replacing it, and deleting it if the difference is empty. It is the typed counterpart of SDiffStore.
+//
+// Parameters:
+//   - storeKey: 	The key where the difference is stored.
+//   - keys: 		The key of the set to subtract from, followed by the keys of the sets to subtract.
+//
+// Returns:
+//   - The number of members in the stored difference.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("set1", 1, 2)
+//	set.TAdd("set2", 2, 3)
+//	count := set.TDiffStore("only1", "set1", "set2")
+//
+// In this example, "only1" holds 1, and 'count' will be 1.
+func (s *TypedSet[T]) TDiffStore(storeKey string, keys ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.store(storeKey, s.diff(keys...))
+}
+
+// TPop removes and returns up to count random members from the set associated with the given key, deleting the
+// key once it is empty. Every member has the same probability of being popped. It is the typed counterpart of SPop.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to pop. If count is 0 or negative, no members are popped.
+//
+// Returns:
+//   - A slice containing the popped members, empty if the key does not exist or count is 0 or negative.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2, 3, 4, 5)
+//	popped := set.TPop("numbers", 2)
+//
+// In this example, two random members are removed from "numbers" and returned in 'popped'.
+func (s *TypedSet[T]) TPop(key string, count int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.records[key]
+	if !ok || count <= 0 {
+		return []T{}
+	}
+
+	members := s.sample(set, count)
+	for _, member := range members {
+		delete(set, member)
+	}
+
+	if len(set) == 0 {
+		delete(s.records, key)
+	}
+
+	return members
+}
+
+// TRandMember returns random members from the set associated with the given key, following the semantics of
+// SRandMember. With a positive count, it returns up to count distinct members. With a negative count, it returns
+// exactly -count members which may repeat, but no more than RandMemberMaxRepeats members.
+// It is the typed counterpart of SRandMember.
+//
+// Parameters:
+//   - key: 	The key associated with the set.
+//   - count: 	The number of random members to return. A negative count allows repeated members.
+//
+// Returns:
+//   - A slice containing the random members, empty if the key does not exist or count is 0.
+//
+// Example:
+//
+//	set := NewTyped[int]()
+//	set.TAdd("numbers", 1, 2, 3)
+//	picked := set.TRandMember("numbers", -5)
+//
+// In this example, 'picked' holds 5 members of "numbers", some of them repeated.
+func (s *TypedSet[T]) TRandMember(key string, count int) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set, ok := s.records[key]
+	if !ok || count == 0 {
+		return []T{}
+	}
+
+	if count > 0 {
+		return s.sample(set, count)
+	}
+
+	if count < -RandMemberMaxRepeats {
+		count = -RandMemberMaxRepeats
+	}
+
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+
+	candidates := typedList(set)
+	members := make([]T, -count)
+
+	for i := range members {
+		members[i] = candidates[s.rng.Intn(len(candidates))]
+	}
+
+	return members
+}
+
+// sample returns up to count distinct members of the set chosen uniformly at random, using a partial
+// Fisher-Yates shuffle.
+func (s *TypedSet[T]) sample(set map[T]struct{}, count int) []T {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+
+	members := typedList(set)
+	count = min(count, len(members))
+
+	for i := 0; i < count; i++ {
+		j := i + s.rng.Intn(len(members)-i)
+		members[i], members[j] = members[j], members[i]
+	}
+
+	return members[:count]
+}
+
+// union returns a new set containing the members of all the sets associated with the keys.
+func (s *TypedSet[T]) union(keys ...string) map[T]struct{} {
+	result := make(map[T]struct{})
+	for _, key := range keys {
+		for item := range s.records[key] {
+			result[item] = keyExists
+		}
+	}
+
+	return result
+}
+
+// inter returns a new set containing the members present in every set associated with the keys,
+// iterating over the smallest set.
+func (s *TypedSet[T]) inter(keys ...string) map[T]struct{} {
+	result := make(map[T]struct{})
+	if len(keys) == 0 {
+		return result
+	}
+
+	sets := make([]map[T]struct{}, len(keys))
+	smallest := 0
+
+	for i, key := range keys {
+		set, ok := s.records[key]
+		if !ok {
+			return result
+		}
+
+		sets[i] = set
+		if len(set) < len(sets[smallest]) {
+			smallest = i
+		}
+	}
+
+	for item := range sets[smallest] {
+		inAll := true
+		for _, set := range sets {
+			if _, exists := set[item]; !exists {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			result[item] = keyExists
+		}
+	}
+
+	return result
+}
+
+// diff returns a new set containing the members of the first set associated with the keys
+// that are not in any of the others, with the same semantics as diffMembers: the other keys that are equal
+// to the first one are ignored, and the difference is empty if any of the other keys does not exist.
+func (s *TypedSet[T]) diff(keys ...string) map[T]struct{} {
+	result := make(map[T]struct{})
+	if len(keys) == 0 {
+		return result
+	}
+
+	for _, key := range keys[1:] {
+		if _, ok := s.records[key]; !ok && key != keys[0] {
+			return result
+		}
+	}
+
+	for item := range s.records[keys[0]] {
+		result[item] = keyExists
+	}
+
+	for _, key := range keys[1:] {
+		if key == keys[0] {
+			continue
+		}
+
+		for item := range s.records[key] {
+			delete(result, item)
+		}
+	}
+
+	return result
+}
+
+// store replaces the set associated with storeKey by result, deleting the key if result is empty.
+func (s *TypedSet[T]) store(storeKey string, result map[T]struct{}) int {
+	if len(result) == 0 {
+		delete(s.records, storeKey)
+		return 0
+	}
+
+	s.records[storeKey] = result
+	return len(result)
+}
+
+// typedList returns all items in the set as a slice.
+func typedList[T comparable](set map[T]struct{}) []T {
+	list := make([]T, 0, len(set))
+	for item := range set {
+		list = append(list, item)
+	}
+
+	return list
+}
@@ -0,0 +1,217 @@
+package jellyset
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Helper function to convert a typed slice to a slice of interfaces.
+func toInterfaces[T any](items []T) []interface{} {
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result
+}
+
+func TestTypedSet_String(t *testing.T) {
+	typed := NewTyped[string]()
+	untyped := New()
+
+	for key, members := range map[string][]string{
+		"set1": {"a", "b", "c", "d"},
+		"set2": {"c", "d", "e"},
+		"set3": {"d", "e", "f"},
+	} {
+		typed.TAdd(key, members...)
+		untyped.SAdd(key, toInterfaces(members)...)
+	}
+
+	t.Run("Add, Remove and Query Members", func(t *testing.T) {
+		// Test the basic operations on a TypedSet of strings.
+		// It ensures that they report the same results as the untyped Set.
+		assertCountEqual(t, typed.TAdd("set1", "a", "z"), untyped.SAdd("set1", "a", "z"))
+		assertCountEqual(t, typed.TRem("set1", "z", "y"), untyped.SRem("set1", "z", "y"))
+		assertCountEqual(t, typed.TCard("set1"), untyped.SCard("set1"))
+		assertKeyExists(t, typed.TIsMember("set1", "a"))
+		assertKeyDoesNotExist(t, typed.TIsMember("set1", "z"))
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TMembers("set1")), untyped.SMembers("set1"), "Add, Remove and Query Members")
+		if fmt.Sprint(typed.TMIsMember("set1", "a", "z")) != fmt.Sprint(untyped.SMIsMember("set1", "a", "z")) {
+			t.Errorf("Expected TMIsMember to match SMIsMember")
+		}
+	})
+
+	t.Run("Union, Intersection and Difference", func(t *testing.T) {
+		// Test the multi-set operations on a TypedSet of strings.
+		// It ensures that they behave identically to the untyped Set.
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TUnion("set1", "set2", "set3")), untyped.SUnion("set1", "set2", "set3"), "Union")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TInter("set1", "set2", "set3")), untyped.SInter("set1", "set2", "set3"), "Intersection")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TDiff("set1", "set2", "set3")), untyped.SDiff("set1", "set2", "set3"), "Difference")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TInter("set1", "set2")), []interface{}{"c", "d"}, "Intersection")
+	})
+
+	t.Run("Store Operations", func(t *testing.T) {
+		// Test the store variants on a TypedSet of strings.
+		// It ensures that the stored sets and counts match the untyped Set.
+		assertCountEqual(t, typed.TUnionStore("union", "set1", "set2"), untyped.SUnionStore("union", "set1", "set2"))
+		assertCountEqual(t, typed.TInterStore("inter", "set1", "set2"), untyped.SInterStore("inter", "set1", "set2"))
+		assertCountEqual(t, typed.TDiffStore("diff", "set1", "set2"), untyped.SDiffStore("diff", "set1", "set2"))
+
+		for _, key := range []string{"union", "inter", "diff"} {
+			assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TMembers(key)), untyped.SMembers(key), key)
+		}
+
+		assertCountEqual(t, typed.TInterStore("inter", "set1", "nonexistent"), 0)
+		assertKeyDoesNotExist(t, typed.TKeyExists("inter"))
+	})
+
+	t.Run("Move and Clear", func(t *testing.T) {
+		// Test moving members and clearing keys of a TypedSet of strings.
+		// It ensures that members are moved between keys and cleared keys no longer exist.
+		assertKeyExists(t, typed.TMove("set3", "moved", "f"))
+		assertKeyDoesNotExist(t, typed.TMove("set3", "moved", "f"))
+		assertKeyExists(t, typed.TIsMember("moved", "f"))
+
+		typed.TClear("moved")
+		assertKeyDoesNotExist(t, typed.TKeyExists("moved"))
+	})
+}
+
+func TestTypedSet_Int(t *testing.T) {
+	typed := NewTyped[int]()
+	untyped := New()
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("mod%d", i%3)
+		typed.TAdd(key, i%30)
+		untyped.SAdd(key, i%30)
+		if i%2 == 0 {
+			typed.TAdd("even", i)
+			untyped.SAdd("even", i)
+		}
+	}
+
+	t.Run("Union, Intersection and Difference", func(t *testing.T) {
+		// Test the multi-set operations on a TypedSet of ints.
+		// It ensures that they behave identically to the untyped Set.
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TUnion("mod0", "mod1", "even")), untyped.SUnion("mod0", "mod1", "even"), "Union")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TInter("mod0", "even")), untyped.SInter("mod0", "even"), "Intersection")
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TDiff("even", "mod0", "mod1")), untyped.SDiff("even", "mod0", "mod1"), "Difference")
+	})
+
+	t.Run("Typed Sets are Independent", func(t *testing.T) {
+		// Test two TypedSets using the same keys.
+		// It ensures that they share no state.
+		other := NewTyped[int]()
+		other.TAdd("even", -1)
+		assertCountEqual(t, other.TCard("even"), 1)
+		assertCountEqual(t, typed.TCard("even"), 50)
+	})
+}
+
+func TestTypedSet_Random(t *testing.T) {
+	members := []int{1, 2, 3, 4, 5}
+
+	t.Run("Pop Random Members", func(t *testing.T) {
+		// Test popping members in several steps, until more than the set holds are asked for.
+		// It ensures that popped members are distinct members of the set, removed from it, and that the key is
+		// deleted once empty.
+		typed := NewTyped[int]()
+		typed.TAdd("numbers", members...)
+
+		popped := typed.TPop("numbers", 2)
+		assertCountEqual(t, len(popped), 2)
+		assertCountEqual(t, typed.TCard("numbers"), 3)
+		for _, member := range popped {
+			assertKeyDoesNotExist(t, typed.TIsMember("numbers", member))
+		}
+
+		popped = append(popped, typed.TPop("numbers", 10)...)
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(popped), toInterfaces(members), "Pop Random Members")
+		assertKeyDoesNotExist(t, typed.TKeyExists("numbers"))
+		assertCountEqual(t, len(typed.TPop("numbers", 1)), 0)
+	})
+
+	t.Run("Pop Non-Positive Count", func(t *testing.T) {
+		// Test popping zero and a negative number of members.
+		// It ensures that nothing is popped.
+		typed := NewTyped[int]()
+		typed.TAdd("numbers", members...)
+		assertCountEqual(t, len(typed.TPop("numbers", 0)), 0)
+		assertCountEqual(t, len(typed.TPop("numbers", -1)), 0)
+		assertCountEqual(t, typed.TCard("numbers"), len(members))
+	})
+
+	t.Run("Random Members Follow SRandMember", func(t *testing.T) {
+		// Test retrieving random members with positive, negative, zero and huge negative counts.
+		// It ensures that the counts behave as for SRandMember and that the set is left unchanged.
+		typed := NewTyped[int]()
+		typed.TAdd("numbers", members...)
+
+		assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TRandMember("numbers", 10)), toInterfaces(members), "Distinct Members")
+		assertCountEqual(t, len(typed.TRandMember("numbers", 3)), 3)
+		assertCountEqual(t, len(typed.TRandMember("numbers", 0)), 0)
+		assertCountEqual(t, len(typed.TRandMember("missing", 3)), 0)
+		assertCountEqual(t, len(typed.TRandMember("numbers", math.MinInt)), RandMemberMaxRepeats)
+
+		repeated := typed.TRandMember("numbers", -20)
+		assertCountEqual(t, len(repeated), 20)
+		for _, member := range repeated {
+			assertKeyExists(t, typed.TIsMember("numbers", member))
+		}
+		assertCountEqual(t, typed.TCard("numbers"), len(members))
+	})
+}
+
+func TestTypedSet_DiffMatchesSDiff(t *testing.T) {
+	typed := NewTyped[string]()
+	untyped := New()
+	typed.TAdd("a", "x", "y", "z")
+	typed.TAdd("b", "y")
+	untyped.SAdd("a", "x", "y", "z")
+	untyped.SAdd("b", "y")
+
+	for _, keys := range [][]string{
+		{"a"},
+		{"a", "b"},
+		{"a", "a"},
+		{"a", "a", "b"},
+		{"a", "missing"},
+		{"missing", "a"},
+		{"missing"},
+		{},
+	} {
+		t.Run(fmt.Sprint(keys), func(t *testing.T) {
+			// Test the difference of the same keys on a TypedSet and a Set holding the same members.
+			// It ensures that TDiff and TDiffStore behave as SDiff and SDiffStore.
+			assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TDiff(keys...)), untyped.SDiff(keys...), "TDiff")
+			assertCountEqual(t, typed.TDiffStore("result", keys...), untyped.SDiffStore("result", keys...))
+			assertSlicesEqualIgnoreOrder(t, toInterfaces(typed.TMembers("result")), untyped.SMembers("result"), "TDiffStore")
+		})
+	}
+}
+
+func TestTypedSet_EmptyKeysAreDeleted(t *testing.T) {
+	typed := NewTyped[int]()
+
+	t.Run("Add Nothing", func(t *testing.T) {
+		// Test adding no members to a key.
+		// It ensures that no empty key is created.
+		assertCountEqual(t, typed.TAdd("nothing"), 0)
+		assertKeyDoesNotExist(t, typed.TKeyExists("nothing"))
+	})
+
+	t.Run("Remove and Move Last Member", func(t *testing.T) {
+		// Test removing and moving the last member of typed sets.
+		// It ensures that the emptied keys no longer exist.
+		typed.TAdd("removed", 1)
+		typed.TRem("removed", 1)
+		assertKeyDoesNotExist(t, typed.TKeyExists("removed"))
+
+		typed.TAdd("src", 1)
+		typed.TMove("src", "dest", 1)
+		assertKeyDoesNotExist(t, typed.TKeyExists("src"))
+		assertKeyExists(t, typed.TKeyExists("dest"))
+	})
+}
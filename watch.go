@@ -0,0 +1,113 @@
+package jellyset
+
+// SInterWatch stores the intersection of the sets associated with the given keys into storeKey, like SInterStore,
+// and keeps it up to date afterwards: a member added to a source is added to storeKey once it is in every source,
+// and a member removed from a source is removed from storeKey. The update is incremental, so the intersection
+// is never recomputed as a whole. Clearing a source with SClear clears storeKey.
+//
+// The members added or removed by the operations reported to OnAdd and OnRemove, such as SAdd, SRem, SPop,
+// SPopSpecific, SCASMember, SMove or SMergeFrom, are propagated. Other changes to the sources, such as replacing
+// them with a store operation or SImport with replace, or letting them expire, are not; call SInterWatch again
+// to recompute the intersection.
+// Watching storeKey again replaces its previous watch, and SInterUnwatch stops it.
+//
+// Parameters:
+//   - storeKey: 	The key under which the intersection is stored and kept up to date.
+//   - keys: 		The keys associated with the sets to intersect.
+//
+// Returns:
+//   - The number of members of the stored intersection.
+//
+// Example:
+//
+//	set := New()
+//	set.SAdd("set1", "a", "b")
+//	set.SAdd("set2", "b")
+//	set.SInterWatch("both", "set1", "set2")
+//	set.SAdd("set2", "a")
+//	set.SRem("set1", "b")
+//	members := set.SMembers("both")
+//
+// In this example, 'members' will contain only "a."
+func (s *Set) SInterWatch(storeKey string, keys ...string) int {
+	s.lock()
+	defer s.unlock()
+
+	s.interWatches[storeKey] = append([]string(nil), keys...)
+	return s.store(storeKey, setOf(s.interMembers(keys...)...))
+}
+
+// SInterUnwatch stops keeping the intersection stored into storeKey by SInterWatch up to date.
+// The stored intersection is kept as it is.
+//
+// Parameters:
+//   - storeKey: 	The key under which the intersection is stored.
+//
+// Returns:
+//   - true if storeKey was watched, false otherwise.
+func (s *Set) SInterUnwatch(storeKey string) bool {
+	s.lock()
+	defer s.unlock()
+
+	_, watched := s.interWatches[storeKey]
+	delete(s.interWatches, storeKey)
+	return watched
+}
+
+// watchAdded adds the member just added to the key to the watched intersections it now belongs to.
+// The caller must hold the write lock.
+func (s *Set) watchAdded(key string, member interface{}) {
+	for storeKey, keys := range s.interWatches {
+		if storeKey == key || !containsKey(keys, key) {
+			continue
+		}
+
+		inAll := true
+		for _, source := range keys {
+			if !s.fieldExists(source, member) {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			if !s.exists(storeKey) {
+				s.put(storeKey, newSet())
+			}
+			s.get(storeKey).add(member)
+		}
+	}
+}
+
+// watchRemoved removes the members just removed from the key from the watched intersections of the key.
+// The caller must hold the write lock.
+func (s *Set) watchRemoved(key string, members ...interface{}) {
+	for storeKey, keys := range s.interWatches {
+		if storeKey == key || !containsKey(keys, key) || !s.exists(storeKey) {
+			continue
+		}
+
+		s.get(storeKey).remove(members...)
+		s.deleteIfEmpty(storeKey)
+	}
+}
+
+// watchCleared clears the watched intersections of the key, once it has been cleared.
+// The caller must hold the write lock.
+func (s *Set) watchCleared(key string) {
+	for storeKey, keys := range s.interWatches {
+		if storeKey != key && containsKey(keys, key) {
+			s.drop(storeKey)
+		}
+	}
+}
+
+// containsKey checks if the key is one of the given keys.
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
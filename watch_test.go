@@ -0,0 +1,143 @@
+package jellyset
+
+import "testing"
+
+func TestSet_SInterWatch(t *testing.T) {
+	t.Run("Initial Intersection", func(t *testing.T) {
+		// Test watching the intersection of sets that already share members.
+		// It ensures that the intersection is stored right away and that its size is returned.
+		set := New()
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "b", "c", "d")
+
+		assertCountEqual(t, set.SInterWatch("result", "set1", "set2"), 2)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"b", "c"}, "Initial Intersection")
+	})
+
+	t.Run("Added Members", func(t *testing.T) {
+		// Test adding members to the sources after the watch is set up.
+		// It ensures that a member joins the intersection only once it is in every source.
+		set := New()
+		set.SAdd("set1", "a")
+		set.SAdd("set2", "b")
+		set.SAdd("set3", "c")
+		set.SInterWatch("result", "set1", "set2", "set3")
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+
+		set.SAdd("set1", "x")
+		set.SAddSlice("set2", []interface{}{"x"})
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+
+		set.MultiSAdd(map[string][]interface{}{"set3": {"x", "y"}})
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"x"}, "Added Members")
+	})
+
+	t.Run("Removed Members", func(t *testing.T) {
+		// Test removing and popping members from the sources after the watch is set up.
+		// It ensures that a member leaves the intersection as soon as it leaves any source,
+		// and that the stored key is deleted once the intersection is empty.
+		set := New()
+		set.SAdd("set1", "a", "b", "c")
+		set.SAdd("set2", "a", "b", "c")
+		set.SInterWatch("result", "set1", "set2")
+
+		set.SRem("set1", "a")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"b", "c"}, "Removed Members")
+
+		popped := set.SPop("set2", 1)
+		assertKeyDoesNotExist(t, set.SIsMember("result", popped[0]))
+
+		set.SRem("set1", "b", "c")
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+
+	t.Run("Moved and Cleared Members", func(t *testing.T) {
+		// Test moving members between sources and clearing a source.
+		// It ensures that the moves are propagated and that clearing a source clears the intersection.
+		set := New()
+		set.SAdd("set1", "a", "b")
+		set.SAdd("set2", "b")
+		set.SInterWatch("result", "set1", "set2")
+
+		set.SMove("set1", "set2", "a")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"b"}, "Moved and Cleared Members")
+
+		set.SAdd("set1", "a")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"a", "b"}, "Moved and Cleared Members")
+
+		set.SClear("set2")
+		assertKeyDoesNotExist(t, set.SKeyExists("result"))
+	})
+
+	t.Run("Named Pops and Swaps", func(t *testing.T) {
+		// Test popping named members from a source and swapping members of a source.
+		// It ensures that both are propagated to the intersection.
+		set := New()
+		set.SAdd("x", 1, 2)
+		set.SAdd("y", 1, 2, 3)
+		set.SInterWatch("both", "x", "y")
+
+		set.SPopSpecific("x", 1)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("both"), []interface{}{2}, "Named Pops and Swaps")
+
+		set.SCASMember("x", 2, 3)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("both"), []interface{}{3}, "Named Pops and Swaps")
+	})
+
+	t.Run("Merged Members", func(t *testing.T) {
+		// Test merging another Set and importing members into the sources, including a source created by the merge.
+		// It ensures that the merged members are propagated to the intersection.
+		set := New()
+		set.SAdd("x", 1, 2)
+		set.SAdd("y", 1)
+		set.SInterWatch("both", "x", "y", "z")
+
+		other := New()
+		other.SAdd("y", 2)
+		other.SAdd("z", 1)
+		set.SMergeFrom(other)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("both"), []interface{}{1}, "Merged Members")
+
+		set.SImport(map[string][]interface{}{"z": {2, 3}}, false)
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("both"), []interface{}{1, 2}, "Merged Members")
+	})
+
+	t.Run("Matches a Full Recompute", func(t *testing.T) {
+		// Test a sequence of mixed additions and removals on overlapping sources.
+		// It ensures that the watched intersection always matches SInter over the same sources.
+		set := New(WithRandSeed(1))
+		set.SInterWatch("result", "set1", "set2", "set3")
+
+		for i := 0; i < 300; i++ {
+			key := []string{"set1", "set2", "set3"}[i%3]
+			if i%4 == 3 {
+				set.SRem(key, i%7)
+			} else {
+				set.SAdd(key, i%7)
+			}
+
+			assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), set.SInter("set1", "set2", "set3"), "Matches a Full Recompute")
+		}
+	})
+
+	t.Run("Unwatch", func(t *testing.T) {
+		// Test stopping a watch and then changing the sources.
+		// It ensures that the stored intersection is kept but no longer updated.
+		set := New()
+		set.SAdd("set1", "a")
+		set.SAdd("set2", "a")
+		set.SInterWatch("result", "set1", "set2")
+
+		if !set.SInterUnwatch("result") {
+			t.Error("Expected SInterUnwatch to report the watch, but it did not")
+		}
+		if set.SInterUnwatch("result") {
+			t.Error("Expected SInterUnwatch to report no watch the second time, but it did")
+		}
+
+		set.SRem("set1", "a")
+		set.SAdd("set1", "b")
+		set.SAdd("set2", "b")
+		assertSlicesEqualIgnoreOrder(t, set.SMembers("result"), []interface{}{"a"}, "Unwatch")
+	})
+}